@@ -0,0 +1,203 @@
+// Package geoip resolves ip addresses to types.CountryCode using local databases.
+package geoip
+
+import (
+	"context"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/proemergotech/types/types"
+)
+
+// Database resolves an ip address to a country, an empty CountryCode is returned for unknown addresses.
+type Database interface {
+	Country(ip net.IP) (types.CountryCode, error)
+}
+
+// OpenFunc opens a database file, eg. OpenMMDB or OpenCSV.
+type OpenFunc func(path string) (Database, error)
+
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+type snapshot struct {
+	db  Database
+	tor TorExitList
+}
+
+// Resolver resolves ip addresses using a database file, and an optional tor exit list whose
+// addresses resolve to TorCountryCode. The files can be reloaded without blocking lookups.
+type Resolver struct {
+	open    OpenFunc
+	dbPath  string
+	torPath string
+
+	current atomic.Value // *snapshot
+
+	mu       sync.Mutex
+	dbState  fileState
+	torState fileState
+	// dbPending and torPending are the states seen by the previous check of Watch
+	dbPending  fileState
+	torPending fileState
+}
+
+// NewResolver opens the database at dbPath with open.
+func NewResolver(dbPath string, open OpenFunc) (*Resolver, error) {
+	r := &Resolver{
+		open:   open,
+		dbPath: dbPath,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// LoadTorExitList loads the tor exit list from path, which is also reloaded on Reload and Watch.
+func (r *Resolver) LoadTorExitList(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := stat(path)
+	if err != nil {
+		return err
+	}
+
+	tor, err := OpenTorExitList(path)
+	if err != nil {
+		return err
+	}
+
+	r.torPath = path
+	r.torState = state
+	r.store(r.load().db, tor)
+
+	return nil
+}
+
+// Country returns TorCountryCode for tor exit nodes, otherwise the country found in the database.
+func (r *Resolver) Country(ip net.IP) (types.CountryCode, error) {
+	s := r.load()
+
+	if s.tor.Contains(ip) {
+		return TorCountryCode, nil
+	}
+
+	return s.db.Country(ip)
+}
+
+// Reload re-reads the database and the tor exit list. Lookups use the previous data until both are loaded,
+// on error the previous data is kept.
+func (r *Resolver) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.reload(true)
+}
+
+// Watch checks the files for changes every interval, and reloads them. Blocks until ctx is done.
+// Reload errors are passed to onError if it is not nil.
+//
+// A changed file is reloaded once its modification time and size are the same for two checks, so a file being
+// written is not loaded truncated. Replacing the files atomically, ie. writing a temporary file and renaming it to
+// the path, is still recommended.
+func (r *Resolver) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.mu.Lock()
+			err := r.reload(false)
+			r.mu.Unlock()
+
+			if err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+func (r *Resolver) reload(force bool) error {
+	current := r.load()
+	if current == nil {
+		current = &snapshot{}
+	}
+	db, tor := current.db, current.tor
+	dbState, torState := r.dbState, r.torState
+	changed := false
+
+	state, err := stat(r.dbPath)
+	if err != nil {
+		return err
+	}
+	if shouldReload(state, r.dbState, &r.dbPending, force) {
+		if db, err = r.open(r.dbPath); err != nil {
+			return err
+		}
+		dbState = state
+		changed = true
+	}
+
+	if r.torPath != "" {
+		if state, err = stat(r.torPath); err != nil {
+			return err
+		}
+		if shouldReload(state, r.torState, &r.torPending, force) {
+			if tor, err = OpenTorExitList(r.torPath); err != nil {
+				return err
+			}
+			torState = state
+			changed = true
+		}
+	}
+
+	if changed {
+		r.dbState, r.torState = dbState, torState
+		r.store(db, tor)
+	}
+
+	return nil
+}
+
+func (r *Resolver) load() *snapshot {
+	s, _ := r.current.Load().(*snapshot)
+	return s
+}
+
+func (r *Resolver) store(db Database, tor TorExitList) {
+	r.current.Store(&snapshot{db: db, tor: tor})
+}
+
+func stat(path string) (fileState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileState{}, err
+	}
+
+	return fileState{modTime: info.ModTime(), size: info.Size()}, nil
+}
+
+// shouldReload reports whether a file in state should be reloaded. Unless force is set, it has to differ from the
+// loaded state, and be the same as the pending state of the previous check, which is updated.
+func shouldReload(state, loaded fileState, pending *fileState, force bool) bool {
+	if force {
+		return true
+	}
+
+	stable := state == *pending
+	*pending = state
+
+	return stable && state != loaded
+}
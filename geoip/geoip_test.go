@@ -0,0 +1,161 @@
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/proemergotech/types/types"
+)
+
+func TestResolver(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "country.csv")
+	torPath := filepath.Join(dir, "tor.txt")
+
+	writeTestFile(t, dbPath, "81.0.0.0,81.255.255.255,HU\n")
+	writeTestFile(t, torPath, "81.2.3.4\n")
+
+	r, err := NewResolver(dbPath, OpenCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectCountry(t, r, "81.2.3.4", "hu")
+
+	if err := r.LoadTorExitList(torPath); err != nil {
+		t.Fatal(err)
+	}
+
+	expectCountry(t, r, "81.2.3.4", TorCountryCode)
+	expectCountry(t, r, "81.2.3.5", "hu")
+	expectCountry(t, r, "1.1.1.1", "")
+
+	writeTestFile(t, dbPath, "81.0.0.0,81.255.255.255,AT\n")
+	writeTestFile(t, torPath, "81.2.3.5\n")
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	expectCountry(t, r, "81.2.3.4", "at")
+	expectCountry(t, r, "81.2.3.5", TorCountryCode)
+
+	writeTestFile(t, dbPath, "81.0.0.0,81.255.255.255,HUN\n")
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expectCountry(t, r, "81.2.3.4", "at")
+}
+
+func TestResolverWatch(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "country.csv")
+
+	writeTestFile(t, dbPath, "81.0.0.0,81.255.255.255,HU\n")
+
+	r, err := NewResolver(dbPath, OpenCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	wg := &sync.WaitGroup{}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.Watch(ctx, time.Millisecond, func(err error) {
+			t.Error(err)
+		})
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				if _, err := r.Country(net.ParseIP("81.2.3.4")); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+
+	writeTestFile(t, dbPath, "81.0.0.0,81.255.255.255,AT\n# changed size\n")
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		code, err := r.Country(net.ParseIP("81.2.3.4"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if code == "at" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("database was not reloaded")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestShouldReload(t *testing.T) {
+	loaded := fileState{modTime: time.Unix(1, 0), size: 10}
+	writing := fileState{modTime: time.Unix(2, 0), size: 5}
+	written := fileState{modTime: time.Unix(3, 0), size: 20}
+
+	for index, test := range []struct {
+		state         fileState
+		pending       fileState
+		force         bool
+		expectedValue bool
+	}{
+		{state: loaded, pending: loaded, expectedValue: false},
+		{state: writing, pending: loaded, expectedValue: false},
+		{state: written, pending: writing, expectedValue: false},
+		{state: written, pending: written, expectedValue: true},
+		{state: loaded, pending: writing, force: true, expectedValue: true},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.state.size, test.expectedValue), func(t *testing.T) {
+			pending := test.pending
+			result := shouldReload(test.state, loaded, &pending, test.force)
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+			if !test.force && pending != test.state {
+				t.Fatalf("expected pending: %v, got: %v", test.state, pending)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func expectCountry(t *testing.T, r *Resolver, ip string, expectedValue types.CountryCode) {
+	t.Helper()
+
+	result, err := r.Country(net.ParseIP(ip))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != expectedValue {
+		t.Fatalf("%s: expected: %v, got: %v", ip, expectedValue, result)
+	}
+}
@@ -0,0 +1,426 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+
+	"github.com/proemergotech/types/types"
+)
+
+var mmdbMetadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+const mmdbDataSectionSeparator = 16
+
+const (
+	mmdbExtended = iota
+	mmdbPointer
+	mmdbString
+	mmdbDouble
+	mmdbBytes
+	mmdbUint16
+	mmdbUint32
+	mmdbMap
+	mmdbInt32
+	mmdbUint64
+	mmdbUint128
+	mmdbArray
+	mmdbContainer
+	mmdbEndMarker
+	mmdbBool
+	mmdbFloat
+)
+
+// mmdb is an in-memory MaxMind DB reader, only decoding what is needed for a country lookup.
+// See: https://maxmind.github.io/MaxMind-DB/
+type mmdb struct {
+	buf        []byte
+	data       []byte
+	nodeCount  uint
+	recordSize uint
+	ipVersion  uint
+	ipv4Start  uint
+}
+
+// OpenMMDB reads a MaxMind DB (eg. GeoLite2-Country.mmdb) from path.
+func OpenMMDB(path string) (Database, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMMDB(buf)
+}
+
+// NewMMDB parses a MaxMind DB, buf must not be modified afterwards.
+func NewMMDB(buf []byte) (Database, error) {
+	metaStart := bytes.LastIndex(buf, mmdbMetadataMarker)
+	if metaStart == -1 {
+		return nil, errors.New("invalid mmdb: metadata not found")
+	}
+	metaStart += len(mmdbMetadataMarker)
+
+	meta, _, err := (&mmdbDecoder{buf: buf[metaStart:]}).decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mmdb metadata: %w", err)
+	}
+	metaMap, ok := meta.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("invalid mmdb metadata: not a map")
+	}
+
+	db := &mmdb{buf: buf}
+	for key, dst := range map[string]*uint{
+		"node_count":  &db.nodeCount,
+		"record_size": &db.recordSize,
+		"ip_version":  &db.ipVersion,
+	} {
+		v, ok := metaMap[key].(uint64)
+		if !ok {
+			return nil, fmt.Errorf("invalid mmdb metadata: missing %s", key)
+		}
+		*dst = uint(v)
+	}
+
+	if db.recordSize != 24 && db.recordSize != 28 && db.recordSize != 32 {
+		return nil, fmt.Errorf("invalid mmdb: unsupported record size: %d", db.recordSize)
+	}
+	if db.ipVersion != 4 && db.ipVersion != 6 {
+		return nil, fmt.Errorf("invalid mmdb: unsupported ip version: %d", db.ipVersion)
+	}
+
+	treeSize := db.nodeCount * db.recordSize / 4
+	if treeSize+mmdbDataSectionSeparator > uint(metaStart-len(mmdbMetadataMarker)) {
+		return nil, errors.New("invalid mmdb: search tree exceeds file size")
+	}
+	db.data = buf[treeSize+mmdbDataSectionSeparator : metaStart-len(mmdbMetadataMarker)]
+
+	if db.ipVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < db.nodeCount; i++ {
+			node = db.record(node, 0)
+		}
+		db.ipv4Start = node
+	}
+
+	return db, nil
+}
+
+func (db *mmdb) Country(ip net.IP) (types.CountryCode, error) {
+	node, bits, err := db.startNode(ip)
+	if err != nil {
+		return "", err
+	}
+
+	for i := 0; i < len(bits)*8 && node < db.nodeCount; i++ {
+		bit := uint(bits[i>>3]>>(7-uint(i&7))) & 1
+		node = db.record(node, bit)
+	}
+
+	if node == db.nodeCount {
+		return "", nil
+	}
+	if node < db.nodeCount {
+		return "", errors.New("invalid mmdb: search tree too deep")
+	}
+
+	offset := node - db.nodeCount - mmdbDataSectionSeparator
+	if offset >= uint(len(db.data)) {
+		return "", errors.New("invalid mmdb: data pointer out of range")
+	}
+
+	d := &mmdbDecoder{buf: db.data}
+	for _, path := range [][]string{{"country", "iso_code"}, {"registered_country", "iso_code"}} {
+		code, err := d.lookupString(offset, path)
+		if err != nil {
+			return "", err
+		}
+		if code != "" {
+			return types.NewCountryCode(code)
+		}
+	}
+
+	return "", nil
+}
+
+func (db *mmdb) startNode(ip net.IP) (uint, []byte, error) {
+	if ip4 := ip.To4(); ip4 != nil {
+		if db.ipVersion == 4 {
+			return 0, ip4, nil
+		}
+		return db.ipv4Start, ip4, nil
+	}
+
+	if ip16 := ip.To16(); ip16 != nil {
+		if db.ipVersion == 4 {
+			return 0, nil, fmt.Errorf("ipv6 address in ipv4-only database: %s", ip)
+		}
+		return 0, ip16, nil
+	}
+
+	return 0, nil, fmt.Errorf("invalid ip address: %v", ip)
+}
+
+func (db *mmdb) record(node uint, bit uint) uint {
+	b := db.buf[node*db.recordSize/4:]
+
+	switch db.recordSize {
+	case 24:
+		b = b[bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(b[bit*4:]))
+	}
+}
+
+type mmdbDecoder struct {
+	buf []byte
+}
+
+// lookupString follows the map keys in path starting at offset, and returns the string found there.
+func (d *mmdbDecoder) lookupString(offset uint, path []string) (string, error) {
+	typ, size, offset, err := d.resolve(offset)
+	if err != nil {
+		return "", err
+	}
+
+	if len(path) == 0 {
+		if typ != mmdbString {
+			return "", nil
+		}
+		if offset+size > uint(len(d.buf)) {
+			return "", errors.New("value out of range")
+		}
+		return string(d.buf[offset : offset+size]), nil
+	}
+
+	if typ != mmdbMap {
+		return "", nil
+	}
+
+	for i := uint(0); i < size; i++ {
+		key, next, err := d.decode(offset)
+		if err != nil {
+			return "", err
+		}
+
+		if key == path[0] {
+			return d.lookupString(next, path[1:])
+		}
+
+		if offset, err = d.skip(next); err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// resolve returns the type, size and payload offset of the value at offset, following a pointer if needed.
+func (d *mmdbDecoder) resolve(offset uint) (int, uint, uint, error) {
+	typ, size, payload, err := d.control(offset)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if typ == mmdbPointer {
+		target, _, err := d.pointer(size, payload)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		typ, size, payload, err = d.control(target)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return typ, size, payload, nil
+}
+
+// skip returns the offset after the value at offset, pointers are not followed.
+func (d *mmdbDecoder) skip(offset uint) (uint, error) {
+	typ, size, payload, err := d.control(offset)
+	if err != nil {
+		return 0, err
+	}
+
+	switch typ {
+	case mmdbPointer:
+		_, next, err := d.pointer(size, payload)
+		return next, err
+	case mmdbMap:
+		size *= 2
+		fallthrough
+	case mmdbArray:
+		for i := uint(0); i < size; i++ {
+			if payload, err = d.skip(payload); err != nil {
+				return 0, err
+			}
+		}
+		return payload, nil
+	case mmdbBool:
+		return payload, nil
+	default:
+		return payload + size, nil
+	}
+}
+
+// decode decodes the value at offset, returns the decoded value and the offset after it.
+func (d *mmdbDecoder) decode(offset uint) (interface{}, uint, error) {
+	typ, size, payload, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typ == mmdbPointer {
+		target, next, err := d.pointer(size, payload)
+		if err != nil {
+			return nil, 0, err
+		}
+		if typ, _, _, err = d.control(target); err == nil && typ == mmdbPointer {
+			return nil, 0, errors.New("pointer to pointer")
+		}
+		value, _, err := d.decode(target)
+
+		return value, next, err
+	}
+
+	if typ != mmdbMap && typ != mmdbArray && typ != mmdbBool && payload+size > uint(len(d.buf)) {
+		return nil, 0, errors.New("value out of range")
+	}
+
+	switch typ {
+	case mmdbString:
+		return string(d.buf[payload : payload+size]), payload + size, nil
+	case mmdbBytes:
+		return append([]byte(nil), d.buf[payload:payload+size]...), payload + size, nil
+	case mmdbDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("invalid double size: %d", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(d.buf[payload:])), payload + size, nil
+	case mmdbFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("invalid float size: %d", size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(d.buf[payload:]))), payload + size, nil
+	case mmdbUint16, mmdbUint32, mmdbUint64, mmdbInt32:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("invalid integer size: %d", size)
+		}
+		var v uint64
+		for _, b := range d.buf[payload : payload+size] {
+			v = v<<8 | uint64(b)
+		}
+		return v, payload + size, nil
+	case mmdbUint128:
+		return append([]byte(nil), d.buf[payload:payload+size]...), payload + size, nil
+	case mmdbBool:
+		return size != 0, payload, nil
+	case mmdbMap:
+		m := make(map[string]interface{}, size)
+		for i := uint(0); i < size; i++ {
+			key, next, err := d.decode(payload)
+			if err != nil {
+				return nil, 0, err
+			}
+			keyStr, ok := key.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("invalid map key type: %T", key)
+			}
+			if m[keyStr], payload, err = d.decode(next); err != nil {
+				return nil, 0, err
+			}
+		}
+		return m, payload, nil
+	case mmdbArray:
+		a := make([]interface{}, size)
+		for i := range a {
+			if a[i], payload, err = d.decode(payload); err != nil {
+				return nil, 0, err
+			}
+		}
+		return a, payload, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported data type: %d", typ)
+	}
+}
+
+// control parses the control byte(s) at offset, returns the type, size and the offset of the payload.
+func (d *mmdbDecoder) control(offset uint) (int, uint, uint, error) {
+	if offset >= uint(len(d.buf)) {
+		return 0, 0, 0, errors.New("offset out of range")
+	}
+
+	ctrl := d.buf[offset]
+	offset++
+
+	typ := int(ctrl >> 5)
+	if typ == mmdbExtended {
+		if offset >= uint(len(d.buf)) {
+			return 0, 0, 0, errors.New("offset out of range")
+		}
+		typ = 7 + int(d.buf[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if typ == mmdbPointer || size < 29 {
+		return typ, size, offset, nil
+	}
+
+	extra := size - 28
+	if offset+extra > uint(len(d.buf)) {
+		return 0, 0, 0, errors.New("offset out of range")
+	}
+
+	var v uint
+	for _, b := range d.buf[offset : offset+extra] {
+		v = v<<8 | uint(b)
+	}
+
+	switch size {
+	case 29:
+		size = 29 + v
+	case 30:
+		size = 285 + v
+	default:
+		size = 65821 + v
+	}
+
+	return typ, size, offset + extra, nil
+}
+
+// pointer returns the offset pointed to, and the offset after the pointer.
+func (d *mmdbDecoder) pointer(size uint, offset uint) (uint, uint, error) {
+	n := (size>>3)&0x3 + 1
+	if offset+n > uint(len(d.buf)) {
+		return 0, 0, errors.New("offset out of range")
+	}
+
+	var v uint
+	if n != 4 {
+		v = size & 0x7
+	}
+	for _, b := range d.buf[offset : offset+n] {
+		v = v<<8 | uint(b)
+	}
+
+	switch n {
+	case 2:
+		v += 2048
+	case 3:
+		v += 526336
+	}
+
+	return v, offset + n, nil
+}
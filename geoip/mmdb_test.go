@@ -0,0 +1,250 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types"
+)
+
+type testNetwork struct {
+	cidr       string
+	country    string
+	registered string
+}
+
+var testNetworks = []testNetwork{
+	{cidr: "81.0.0.0/8", country: "HU"},
+	{cidr: "81.1.0.0/16", country: "AT"},
+	{cidr: "10.0.0.0/8", registered: "US"},
+	{cidr: "2001:db8::/32", country: "DE"},
+}
+
+func TestMMDBCountry(t *testing.T) {
+	for _, recordSize := range []int{24, 28, 32} {
+		for _, ipVersion := range []int{4, 6} {
+			db, err := NewMMDB(buildTestMMDB(t, recordSize, ipVersion, testNetworks))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for index, test := range []struct {
+				ip            string
+				expectedValue types.CountryCode
+				expectedError string
+			}{
+				{
+					ip:            "81.2.3.4",
+					expectedValue: "hu",
+				},
+				{
+					ip:            "81.1.3.4",
+					expectedValue: "at",
+				},
+				{
+					ip:            "10.20.30.40",
+					expectedValue: "us",
+				},
+				{
+					ip:            "1.1.1.1",
+					expectedValue: "",
+				},
+				{
+					ip:            "::ffff:81.2.3.4",
+					expectedValue: "hu",
+				},
+				{
+					ip:            "2001:db8::1",
+					expectedValue: "de",
+					expectedError: map[int]string{4: "ipv4-only database"}[ipVersion],
+				},
+				{
+					ip:            "2001:db9::1",
+					expectedValue: "",
+					expectedError: map[int]string{4: "ipv4-only database"}[ipVersion],
+				},
+			} {
+				t.Run(fmt.Sprintf("Case %d/%d/%d: %v -> %v", recordSize, ipVersion, index+1, test.ip, test.expectedValue), func(t *testing.T) {
+					result, err := db.Country(net.ParseIP(test.ip))
+					if err != nil {
+						if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+							return
+						}
+						t.Fatal(err)
+					} else if test.expectedError != "" {
+						t.Errorf("expected error: %s, got none", test.expectedError)
+					}
+					if result != test.expectedValue {
+						t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestMMDBInvalid(t *testing.T) {
+	valid := buildTestMMDB(t, 24, 6, testNetworks)
+
+	for index, test := range []struct {
+		buf           []byte
+		expectedError string
+	}{
+		{
+			buf:           nil,
+			expectedError: "metadata not found",
+		},
+		{
+			buf:           valid[:100],
+			expectedError: "metadata not found",
+		},
+		{
+			buf:           valid[bytes.LastIndex(valid, mmdbMetadataMarker):],
+			expectedError: "search tree exceeds file size",
+		},
+		{
+			buf:           append(append([]byte(nil), mmdbMetadataMarker...), 0xe1, 0x42, 'x'),
+			expectedError: "invalid mmdb metadata",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test.expectedError), func(t *testing.T) {
+			_, err := NewMMDB(test.buf)
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Fatalf("expected error: %s, got: %v", test.expectedError, err)
+			}
+		})
+	}
+}
+
+// buildTestMMDB writes a minimal MaxMind DB, see: https://maxmind.github.io/MaxMind-DB/
+func buildTestMMDB(t *testing.T, recordSize int, ipVersion int, networks []testNetwork) []byte {
+	t.Helper()
+
+	type record struct {
+		node int
+		data int
+	}
+	nodes := [][2]record{{{node: -1, data: -1}, {node: -1, data: -1}}}
+
+	var data bytes.Buffer
+	countryKey := -1
+	writeKey := func(key string) {
+		// repeated "country" keys are stored as pointers, like the real databases do
+		if key == "country" {
+			if countryKey >= 0 {
+				data.Write([]byte{1<<5 | byte(countryKey>>8), byte(countryKey)})
+				return
+			}
+			countryKey = data.Len()
+		}
+		writeTestMMDBString(&data, key)
+	}
+
+	for _, network := range networks {
+		_, ipNet, err := net.ParseCIDR(network.cidr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ip, ones := ipNet.IP.To16(), 0
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			ones, _ = ipNet.Mask.Size()
+			if ipVersion == 6 {
+				ip = append(make([]byte, 12), ip4...)
+				ones += 96
+			} else {
+				ip = ip4
+			}
+		} else {
+			if ipVersion == 4 {
+				continue
+			}
+			ones, _ = ipNet.Mask.Size()
+		}
+
+		offset := data.Len()
+		// {"continent": {"code": "EU"}, "country"|"registered_country": {"iso_code": code}}
+		data.WriteByte(mmdbMap<<5 | 2)
+		writeTestMMDBString(&data, "continent")
+		data.WriteByte(mmdbMap<<5 | 1)
+		writeTestMMDBString(&data, "code")
+		writeTestMMDBString(&data, "EU")
+		if network.country != "" {
+			writeKey("country")
+		} else {
+			writeKey("registered_country")
+		}
+		data.WriteByte(mmdbMap<<5 | 1)
+		writeTestMMDBString(&data, "iso_code")
+		writeTestMMDBString(&data, network.country+network.registered)
+
+		node := 0
+		for i := 0; i < ones; i++ {
+			bit := ip[i/8] >> (7 - uint(i%8)) & 1
+			if i == ones-1 {
+				nodes[node][bit] = record{node: -1, data: offset}
+				break
+			}
+			if nodes[node][bit].node < 0 {
+				// a more specific network inside a less specific one inherits its data
+				inherited := nodes[node][bit]
+				nodes = append(nodes, [2]record{inherited, inherited})
+				nodes[node][bit] = record{node: len(nodes) - 1, data: -1}
+			}
+			node = nodes[node][bit].node
+		}
+	}
+
+	nodeCount := len(nodes)
+	var buf bytes.Buffer
+	for _, n := range nodes {
+		var values [2]uint32
+		for i, r := range n {
+			switch {
+			case r.node >= 0:
+				values[i] = uint32(r.node)
+			case r.data >= 0:
+				values[i] = uint32(nodeCount + mmdbDataSectionSeparator + r.data)
+			default:
+				values[i] = uint32(nodeCount)
+			}
+		}
+
+		switch recordSize {
+		case 24:
+			buf.Write([]byte{byte(values[0] >> 16), byte(values[0] >> 8), byte(values[0])})
+			buf.Write([]byte{byte(values[1] >> 16), byte(values[1] >> 8), byte(values[1])})
+		case 28:
+			buf.Write([]byte{byte(values[0] >> 16), byte(values[0] >> 8), byte(values[0])})
+			buf.WriteByte(byte(values[0]>>24)<<4 | byte(values[1]>>24))
+			buf.Write([]byte{byte(values[1] >> 16), byte(values[1] >> 8), byte(values[1])})
+		default:
+			_ = binary.Write(&buf, binary.BigEndian, values)
+		}
+	}
+
+	buf.Write(make([]byte, mmdbDataSectionSeparator))
+	buf.Write(data.Bytes())
+	buf.Write(mmdbMetadataMarker)
+
+	buf.WriteByte(mmdbMap<<5 | 4)
+	writeTestMMDBString(&buf, "node_count")
+	buf.Write([]byte{mmdbUint32<<5 | 4, byte(nodeCount >> 24), byte(nodeCount >> 16), byte(nodeCount >> 8), byte(nodeCount)})
+	writeTestMMDBString(&buf, "record_size")
+	buf.Write([]byte{mmdbUint16<<5 | 1, byte(recordSize)})
+	writeTestMMDBString(&buf, "ip_version")
+	buf.Write([]byte{mmdbUint16<<5 | 1, byte(ipVersion)})
+	writeTestMMDBString(&buf, "database_type")
+	writeTestMMDBString(&buf, "Test-Country")
+
+	return buf.Bytes()
+}
+
+func writeTestMMDBString(buf *bytes.Buffer, s string) {
+	buf.WriteByte(mmdbString<<5 | byte(len(s)))
+	buf.WriteString(s)
+}
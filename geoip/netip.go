@@ -0,0 +1,13 @@
+package geoip
+
+import (
+	"net"
+	"net/netip"
+
+	"github.com/proemergotech/types/types"
+)
+
+// CountryAddr is Country for netip.Addr.
+func (r *Resolver) CountryAddr(addr netip.Addr) (types.CountryCode, error) {
+	return r.Country(net.IP(addr.Unmap().AsSlice()))
+}
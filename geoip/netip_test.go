@@ -0,0 +1,27 @@
+package geoip
+
+import (
+	"net/netip"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolverCountryAddr(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "country.csv")
+	writeTestFile(t, dbPath, "81.0.0.0,81.255.255.255,HU\n")
+
+	r, err := NewResolver(dbPath, OpenCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, addr := range []string{"81.2.3.4", "::ffff:81.2.3.4"} {
+		result, err := r.CountryAddr(netip.MustParseAddr(addr))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result != "hu" {
+			t.Fatalf("%s: expected: hu, got: %v", addr, result)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/proemergotech/types/types"
+)
+
+type ipRange struct {
+	start   [16]byte
+	end     [16]byte
+	country types.CountryCode
+}
+
+// rangeDatabase is a sorted list of non-overlapping ip ranges.
+type rangeDatabase []ipRange
+
+// OpenCSV reads a csv range database from path, see: NewCSV.
+func OpenCSV(path string) (Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewCSV(f)
+}
+
+// NewCSV reads a csv range database with "first ip,last ip,country code" records, eg. the DB-IP
+// or IP2Location LITE country databases. Records with an unknown country ("-", "ZZ") are skipped.
+func NewCSV(r io.Reader) (Database, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	var db rangeDatabase
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) < 3 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("invalid csv database: line %d: expected at least 3 fields", line)
+		}
+
+		code := strings.TrimSpace(record[2])
+		if code == "-" || strings.EqualFold(code, "zz") {
+			continue
+		}
+
+		start, end := parseIP(record[0]), parseIP(record[1])
+		if start == nil || end == nil {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("invalid csv database: line %d: invalid ip range: %s-%s", line, record[0], record[1])
+		}
+
+		country, err := types.NewCountryCode(code)
+		if err != nil {
+			line, _ := reader.FieldPos(2)
+			return nil, fmt.Errorf("invalid csv database: line %d: %w", line, err)
+		}
+
+		r := ipRange{country: country}
+		copy(r.start[:], start)
+		copy(r.end[:], end)
+		if bytes.Compare(r.start[:], r.end[:]) > 0 {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("invalid csv database: line %d: invalid ip range: %s-%s", line, record[0], record[1])
+		}
+
+		db = append(db, r)
+	}
+
+	sort.Slice(db, func(i, j int) bool {
+		return bytes.Compare(db[i].start[:], db[j].start[:]) < 0
+	})
+
+	for i := 1; i < len(db); i++ {
+		if bytes.Compare(db[i-1].end[:], db[i].start[:]) >= 0 {
+			return nil, fmt.Errorf("invalid csv database: overlapping ranges: %s-%s, %s-%s",
+				net.IP(db[i-1].start[:]), net.IP(db[i-1].end[:]), net.IP(db[i].start[:]), net.IP(db[i].end[:]))
+		}
+	}
+
+	return db, nil
+}
+
+func (db rangeDatabase) Country(ip net.IP) (types.CountryCode, error) {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return "", fmt.Errorf("invalid ip address: %v", ip)
+	}
+
+	i := sort.Search(len(db), func(i int) bool {
+		return bytes.Compare(db[i].end[:], ip16) >= 0
+	})
+	if i == len(db) || bytes.Compare(db[i].start[:], ip16) > 0 {
+		return "", nil
+	}
+
+	return db[i].country, nil
+}
+
+// parseIP parses an ip address in text or in decimal integer form (IP2Location), always returning 16 bytes.
+func parseIP(s string) net.IP {
+	s = strings.TrimSpace(s)
+
+	if ip := net.ParseIP(s); ip != nil {
+		return ip.To16()
+	}
+
+	if s == "" || strings.Trim(s, "0123456789") != "" {
+		return nil
+	}
+
+	// decimal integers up to 2^128-1, ipv4 if it fits into 32 bits
+	var ip [16]byte
+	for _, c := range s {
+		carry := uint(c - '0')
+		for i := len(ip) - 1; i >= 0; i-- {
+			v := uint(ip[i])*10 + carry
+			ip[i] = byte(v)
+			carry = v >> 8
+		}
+		if carry != 0 {
+			return nil
+		}
+	}
+
+	if bytes.Equal(ip[:12], make([]byte, 12)) {
+		return net.IPv4(ip[12], ip[13], ip[14], ip[15]).To16()
+	}
+
+	return ip[:]
+}
@@ -0,0 +1,103 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types"
+)
+
+const testCSV = `# first,last,country
+81.0.0.0,81.0.255.255,HU
+"1359020032","1359085567","AT"
+10.0.0.0,10.255.255.255,-
+2001:db8::,2001:db8:ffff:ffff:ffff:ffff:ffff:ffff,DE
+`
+
+func TestCSVCountry(t *testing.T) {
+	db, err := NewCSV(strings.NewReader(testCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for index, test := range []struct {
+		ip            string
+		expectedValue types.CountryCode
+	}{
+		{
+			ip:            "81.0.0.0",
+			expectedValue: "hu",
+		},
+		{
+			ip:            "81.0.255.255",
+			expectedValue: "hu",
+		},
+		{
+			ip:            "81.1.0.1",
+			expectedValue: "at",
+		},
+		{
+			ip:            "81.2.0.1",
+			expectedValue: "",
+		},
+		{
+			ip:            "10.1.1.1",
+			expectedValue: "",
+		},
+		{
+			ip:            "2001:db8::1",
+			expectedValue: "de",
+		},
+		{
+			ip:            "::1",
+			expectedValue: "",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.ip, test.expectedValue), func(t *testing.T) {
+			result, err := db.Country(net.ParseIP(test.ip))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCSVInvalid(t *testing.T) {
+	for index, test := range []struct {
+		csv           string
+		expectedError string
+	}{
+		{
+			csv:           "81.0.0.0,81.0.255.255\n",
+			expectedError: "line 1: expected at least 3 fields",
+		},
+		{
+			csv:           "81.0.0.0,81.0.255.255,HU\n81.0.0.0,foo,AT\n",
+			expectedError: "line 2: invalid ip range",
+		},
+		{
+			csv:           "81.0.255.255,81.0.0.0,HU\n",
+			expectedError: "line 1: invalid ip range",
+		},
+		{
+			csv:           "81.0.0.0,81.0.255.255,HUN\n",
+			expectedError: "line 1: invalid country code",
+		},
+		{
+			csv:           "81.0.0.0,81.0.255.255,HU\n81.0.1.0,81.0.1.255,AT\n",
+			expectedError: "overlapping ranges",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test.expectedError), func(t *testing.T) {
+			_, err := NewCSV(strings.NewReader(test.csv))
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Fatalf("expected error: %s, got: %v", test.expectedError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package geoip
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/proemergotech/types/types"
+)
+
+// TorCountryCode is returned for tor exit nodes.
+const TorCountryCode types.CountryCode = "t1"
+
+// TorExitList is a set of tor exit node addresses.
+type TorExitList map[[16]byte]struct{}
+
+// OpenTorExitList reads a tor exit node list from path, see: NewTorExitList.
+func OpenTorExitList(path string) (TorExitList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewTorExitList(f)
+}
+
+// NewTorExitList reads a tor exit node list, either in the bulk exit list format (one ip per line,
+// https://check.torproject.org/torbulkexitlist), or in the exit-addresses format ("ExitAddress <ip> <date>" lines).
+func NewTorExitList(r io.Reader) (TorExitList, error) {
+	list := make(TorExitList)
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		switch {
+		case len(fields) == 1:
+		case fields[0] == "ExitAddress" && len(fields) > 1:
+			fields = fields[1:]
+		case fields[0] == "ExitNode" || fields[0] == "Published" || fields[0] == "LastStatus":
+			continue
+		default:
+			return nil, fmt.Errorf("invalid tor exit list: line %d: %s", line, text)
+		}
+
+		ip := net.ParseIP(fields[0])
+		if ip == nil {
+			return nil, fmt.Errorf("invalid tor exit list: line %d: invalid ip address: %s", line, fields[0])
+		}
+
+		var key [16]byte
+		copy(key[:], ip.To16())
+		list[key] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (l TorExitList) Contains(ip net.IP) bool {
+	ip16 := ip.To16()
+	if ip16 == nil {
+		return false
+	}
+
+	var key [16]byte
+	copy(key[:], ip16)
+	_, ok := l[key]
+
+	return ok
+}
@@ -0,0 +1,71 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestTorExitList(t *testing.T) {
+	for index, test := range []struct {
+		list          string
+		ip            string
+		expectedValue bool
+		expectedError string
+	}{
+		{
+			list:          "1.2.3.4\n5.6.7.8\n",
+			ip:            "5.6.7.8",
+			expectedValue: true,
+		},
+		{
+			list:          "1.2.3.4\n5.6.7.8\n",
+			ip:            "::ffff:1.2.3.4",
+			expectedValue: true,
+		},
+		{
+			list:          "1.2.3.4\n",
+			ip:            "1.2.3.5",
+			expectedValue: false,
+		},
+		{
+			list: "ExitNode 0011BD2485AD45D984EC4159C88FC066E5E3300E\n" +
+				"Published 2022-03-10 01:02:03\n" +
+				"LastStatus 2022-03-10 02:00:00\n" +
+				"ExitAddress 162.247.74.201 2022-03-10 02:10:11\n",
+			ip:            "162.247.74.201",
+			expectedValue: true,
+		},
+		{
+			list:          "# comment\n\n2001:db8::1\n",
+			ip:            "2001:db8::1",
+			expectedValue: true,
+		},
+		{
+			list:          "1.2.3.4\nfoo\n",
+			expectedError: "line 2: invalid ip address",
+		},
+		{
+			list:          "1.2.3.4 foo\n",
+			expectedError: "line 1",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.ip, test.expectedValue), func(t *testing.T) {
+			list, err := NewTorExitList(strings.NewReader(test.list))
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			result := list.Contains(net.ParseIP(test.ip))
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
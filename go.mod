@@ -1,5 +1,8 @@
 module github.com/proemergotech/types
 
-go 1.17
+go 1.18
 
-require github.com/ugorji/go v1.1.1
+require (
+	github.com/ugorji/go v1.1.1
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+)
+
+// binaryTagNumeric starts the compact binary form of codes, followed by the numeric code as 2 bytes, big-endian.
+// Text forms start with a letter or digit, so the tag tells the two apart.
+const binaryTagNumeric = 0x01
+
+var binaryCompact int32
+
+// SetBinaryCompact sets whether MarshalBinary of CountryCode, Currency and Language returns a 3 byte compact form:
+// a tag byte and the ISO 3166-1 numeric, ISO 4217 numeric or packed 10-bit language code. Codes without a number, eg.
+// registered currencies or T1, are marshalled as text. UnmarshalBinary accepts both forms regardless, so enable it
+// once all readers are upgraded.
+func SetBinaryCompact(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+
+	atomic.StoreInt32(&binaryCompact, v)
+}
+
+func isBinaryCompact() bool {
+	return atomic.LoadInt32(&binaryCompact) == 1
+}
+
+func marshalCodeBinary[T ~string](c T, numeric func(T) (uint64, bool)) ([]byte, error) {
+	if isBinaryCompact() {
+		return compactCodeBinary(c, numeric), nil
+	}
+
+	return marshalCodeText(withOutputCase(c))
+}
+
+// compactCodeBinary returns the tag byte and the numeric code, or the text of codes without a number.
+func compactCodeBinary[T ~string](c T, numeric func(T) (uint64, bool)) []byte {
+	if n, ok := numeric(c); ok {
+		return []byte{binaryTagNumeric, byte(n >> 8), byte(n)}
+	}
+
+	return []byte(withOutputCase(c))
+}
+
+func unmarshalCodeBinary[T ~string](c *T, b []byte, kind string, parse func(string) (T, error), fromNumeric func(uint64) (T, bool)) error {
+	if len(b) == 0 || b[0] != binaryTagNumeric {
+		return unmarshalCodeText(c, b, parse)
+	}
+
+	if len(b) != 3 {
+		return &InvalidCodeError{Kind: kind, Input: hex.EncodeToString(b), Reason: ErrInvalidFormat}
+	}
+
+	return decodeNumericCode(c, kind, uint64(b[1])<<8|uint64(b[2]), fromNumeric)
+}
+
+func decodeNumericCode[T ~string](c *T, kind string, n uint64, fromNumeric func(uint64) (T, bool)) error {
+	code, ok := fromNumeric(n)
+	if !ok {
+		return &InvalidCodeError{Kind: kind, Input: strconv.FormatUint(n, 10), Reason: ErrUnknownCode}
+	}
+
+	*c = code
+
+	return nil
+}
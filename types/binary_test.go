@@ -0,0 +1,211 @@
+package types
+
+import (
+	"bytes"
+	"encoding"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types/codetest"
+)
+
+func TestBinaryCompact(t *testing.T) {
+	RegisterCryptoCurrencies()
+
+	for index, test := range []struct {
+		value         encoding.BinaryMarshaler
+		compact       bool
+		expectedValue []byte
+	}{
+		{
+			value:         CountryCode("hu"),
+			compact:       true,
+			expectedValue: []byte{0x01, 0x01, 0x5c},
+		},
+		{
+			value:         CountryCode("t1"),
+			compact:       true,
+			expectedValue: []byte("t1"),
+		},
+		{
+			value:         Currency("eur"),
+			compact:       true,
+			expectedValue: []byte{0x01, 0x03, 0xd2},
+		},
+		{
+			value:         Currency("btc"),
+			compact:       true,
+			expectedValue: []byte("btc"),
+		},
+		{
+			value:         Currency("mxp"),
+			compact:       true,
+			expectedValue: []byte("mxp"),
+		},
+		{
+			value:         Language("hu"),
+			compact:       true,
+			expectedValue: []byte{0x01, 0x00, 0xca},
+		},
+		{
+			value:         Language("zz"),
+			compact:       true,
+			expectedValue: []byte{0x01, 0x02, 0xa3},
+		},
+		{
+			value:         Language(""),
+			compact:       true,
+			expectedValue: []byte{},
+		},
+		{
+			value:         Currency("eur"),
+			compact:       false,
+			expectedValue: []byte("eur"),
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %x", index+1, test.value, test.expectedValue), func(t *testing.T) {
+			defer SetBinaryCompact(false)
+			SetBinaryCompact(test.compact)
+
+			result, err := test.value.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(result, test.expectedValue) {
+				t.Fatalf("expected: %x, got: %x", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestBinaryUnmarshal(t *testing.T) {
+	for index, test := range []struct {
+		b    []byte
+		into interface {
+			encoding.BinaryUnmarshaler
+			String() string
+		}
+		expectedValue string
+		expectedError string
+	}{
+		{
+			b:             []byte("EUR"),
+			into:          new(Currency),
+			expectedValue: "eur",
+		},
+		{
+			b:             []byte{0x01, 0x03, 0xd2},
+			into:          new(Currency),
+			expectedValue: "eur",
+		},
+		{
+			b:             []byte{0x01, 0x00, 0x68},
+			into:          new(CountryCode),
+			expectedValue: "mm",
+		},
+		{
+			b:             []byte{0x01, 0x00, 0x00},
+			into:          new(Language),
+			expectedValue: "aa",
+		},
+		{
+			b:             []byte{0x01, 0x03, 0xe8},
+			into:          new(Currency),
+			expectedError: "invalid currency: 1000: unknown code",
+		},
+		{
+			b:             []byte{0x01, 0x02, 0xa4},
+			into:          new(Language),
+			expectedError: "invalid language: 676: unknown code",
+		},
+		{
+			b:             []byte{0x01, 0x03},
+			into:          new(CountryCode),
+			expectedError: "invalid country code: 0103",
+		},
+		{
+			b:             []byte("h1"),
+			into:          new(Language),
+			expectedError: "invalid language: h1",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %x -> %v", index+1, test.b, test.expectedValue), func(t *testing.T) {
+			err := test.into.UnmarshalBinary(test.b)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result := test.into.String(); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func FuzzCountryCodeBinary(f *testing.F) {
+	fuzzCodeBinary[CountryCode](f, "hu", "T1", "cs", "yu", "xk", "eu")
+}
+
+func FuzzCurrencyBinary(f *testing.F) {
+	RegisterCryptoCurrencies()
+
+	fuzzCodeBinary[Currency](f, "eur", "HUF", "dem", "mxp", "mxn", "btc", "xxx")
+}
+
+func FuzzLanguageBinary(f *testing.F) {
+	fuzzCodeBinary[Language](f, "hu", "EN", "iw", "zz")
+}
+
+// fuzzCodeBinary checks that any input accepted by UnmarshalBinary survives a compact round-trip, and that the
+// compact form of the result is stable.
+func fuzzCodeBinary[T codetest.Code, P codetest.CodePtr[T]](f *testing.F, seeds ...string) {
+	SetBinaryCompact(true)
+	defer SetBinaryCompact(false)
+
+	for _, seed := range seeds {
+		f.Add([]byte(seed))
+
+		var code T
+		if err := P(&code).UnmarshalBinary([]byte(seed)); err != nil {
+			f.Fatal(err)
+		}
+		b, err := code.MarshalBinary()
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(b)
+	}
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var code T
+		if err := P(&code).UnmarshalBinary(b); err != nil {
+			return
+		}
+
+		compact, err := code.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var result T
+		if err := P(&result).UnmarshalBinary(compact); err != nil {
+			t.Fatalf("unmarshal %x: %v", compact, err)
+		}
+		if result != code {
+			t.Fatalf("expected: %v, got: %v", code, result)
+		}
+
+		again, err := result.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, compact) {
+			t.Fatalf("expected: %x, got: %x", compact, again)
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CodeSpec describes a code type for Code, implement it on an empty struct, eg.
+//
+//	type airportCodeSpec struct{}
+//
+//	func (airportCodeSpec) CodeName() string           { return "airport code" }
+//	func (airportCodeSpec) ValidCode(code string) bool { return airportCodeValidator.MatchString(code) }
+//
+//	type AirportCode = types.Code[airportCodeSpec]
+type CodeSpec interface {
+	// CodeName is used in error messages, eg. "invalid airport code: XXXX".
+	CodeName() string
+	// ValidCode tells if the code is valid, it is called with the input before lower-casing.
+	ValidCode(code string) bool
+}
+
+// Code is a lower-case string code validated by S, with the same marshalling as CountryCode, Currency and Language.
+type Code[S CodeSpec] string
+
+func NewCode[S CodeSpec](code string) (Code[S], error) {
+	var spec S
+
+	return parseCode[Code[S]](spec, code)
+}
+
+func (c Code[S]) String() string {
+	return string(c)
+}
+
+func (c Code[S]) MarshalText() ([]byte, error) {
+	return marshalCodeText(withOutputCase(c))
+}
+
+func (c *Code[S]) UnmarshalText(b []byte) error {
+	return unmarshalCodeText(c, b, NewCode[S])
+}
+
+func (c Code[S]) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(withOutputCase(c))
+}
+
+func (c *Code[S]) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeJSON(c, b, NewCode[S])
+}
+
+func (c Code[S]) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(withOutputCase(c))
+}
+
+func (c *Code[S]) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(c, node, NewCode[S])
+}
+
+func (c Code[S]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(withXMLOutputCase(c), e, start)
+}
+
+func (c *Code[S]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(c, d, start, NewCode[S])
+}
+
+func (c Code[S]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(withXMLOutputCase(c), name)
+}
+
+func (c *Code[S]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(c, attr, NewCode[S])
+}
+
+func (c Code[S]) MarshalBinary() ([]byte, error) {
+	return marshalCodeText(withOutputCase(c))
+}
+
+func (c *Code[S]) UnmarshalBinary(b []byte) error {
+	return unmarshalCodeText(c, b, NewCode[S])
+}
+
+func (c Code[S]) Value() (driver.Value, error) {
+	return codeValue(withOutputCase(c))
+}
+
+func (c *Code[S]) Scan(src interface{}) error {
+	var spec S
+
+	return scanCode(c, src, NewCode[S], spec.CodeName())
+}
+
+// OutputCase is the case of marshalled codes, see: SetOutputCase.
+type OutputCase int
+
+const (
+	// LowerCase codes, eg. "eur", this is the default.
+	LowerCase OutputCase = iota
+	// UpperCase codes, eg. "EUR", as expected by most external APIs.
+	UpperCase
+)
+
+var (
+	outputCasesMu  sync.RWMutex
+	outputCases    = make(map[interface{}]OutputCase)
+	xmlOutputCases = make(map[interface{}]OutputCase)
+)
+
+// SetOutputCase sets the case of codes of type T emitted by MarshalText, MarshalJSON, MarshalYAML, MarshalXML,
+// MarshalBinary and Value, eg. SetOutputCase[Currency](UpperCase). Parsing stays case-insensitive and String returns the lower-case code.
+// Time zone names are case-sensitive, TimeZone is not affected.
+func SetOutputCase[T ~string](c OutputCase) {
+	outputCasesMu.Lock()
+	defer outputCasesMu.Unlock()
+
+	outputCases[interface{}(T(""))] = c
+}
+
+// SetXMLOutputCase sets the case of codes of type T emitted by MarshalXML and MarshalXMLAttr, overriding
+// SetOutputCase in XML only, eg. SetXMLOutputCase[Currency](UpperCase) for Ccy="EUR" in ISO 20022 messages.
+func SetXMLOutputCase[T ~string](c OutputCase) {
+	outputCasesMu.Lock()
+	defer outputCasesMu.Unlock()
+
+	xmlOutputCases[interface{}(T(""))] = c
+}
+
+// ResetXMLOutputCase removes the XML output case set for T by SetXMLOutputCase, so XML follows SetOutputCase again.
+func ResetXMLOutputCase[T ~string]() {
+	outputCasesMu.Lock()
+	defer outputCasesMu.Unlock()
+
+	delete(xmlOutputCases, interface{}(T("")))
+}
+
+// withOutputCase returns c in the output case set for T.
+func withOutputCase[T ~string](c T) T {
+	outputCasesMu.RLock()
+	outputCase := outputCases[interface{}(T(""))]
+	outputCasesMu.RUnlock()
+
+	return toOutputCase(c, outputCase)
+}
+
+// withXMLOutputCase returns c in the XML output case set for T, or in its output case.
+func withXMLOutputCase[T ~string](c T) T {
+	outputCasesMu.RLock()
+	outputCase, ok := xmlOutputCases[interface{}(T(""))]
+	if !ok {
+		outputCase = outputCases[interface{}(T(""))]
+	}
+	outputCasesMu.RUnlock()
+
+	return toOutputCase(c, outputCase)
+}
+
+func toOutputCase[T ~string](c T, outputCase OutputCase) T {
+	if outputCase == UpperCase {
+		return T(strings.ToUpper(string(c)))
+	}
+
+	return c
+}
+
+// parseCode validates code with spec and lower-cases it, the empty code is valid.
+func parseCode[T ~string](spec CodeSpec, code string) (T, error) {
+	if code == "" {
+		return "", nil
+	}
+
+	if !spec.ValidCode(code) {
+		return "", &InvalidCodeError{Kind: spec.CodeName(), Input: code, Reason: ErrInvalidFormat}
+	}
+
+	return T(strings.ToLower(code)), nil
+}
+
+func marshalCodeText[T ~string](c T) ([]byte, error) {
+	return []byte(c), nil
+}
+
+func unmarshalCodeText[T ~string](c *T, b []byte, parse func(string) (T, error)) error {
+	code, err := parse(string(b))
+	if err != nil {
+		return err
+	}
+
+	*c = code
+
+	return nil
+}
+
+// marshalCodeJSON marshals the empty code to null.
+func marshalCodeJSON[T ~string](c T) ([]byte, error) {
+	if c == "" {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Quote(string(c))), nil
+}
+
+// unmarshalCodeJSON leaves c unchanged for null.
+func unmarshalCodeJSON[T ~string](c *T, b []byte, parse func(string) (T, error)) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+
+	return unmarshalCodeText(c, []byte(str), parse)
+}
+
+// codeValue stores the empty code as NULL.
+func codeValue[T ~string](c T) (driver.Value, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	return string(c), nil
+}
+
+// scanCode scans NULL to the empty code, and strings from string, []byte or sql.RawBytes sources. typeName is used
+// in the error of unsupported sources.
+func scanCode[T ~string](c *T, src interface{}, parse func(string) (T, error), typeName string) error {
+	var err error
+
+	switch src := src.(type) {
+	case nil:
+		*c = ""
+	case string:
+		*c, err = parse(src)
+	case []byte:
+		*c, err = parse(string(src))
+	case sql.RawBytes:
+		*c, err = parse(string(src))
+	default:
+		err = fmt.Errorf("cannot convert %T to %s", src, typeName)
+	}
+
+	return err
+}
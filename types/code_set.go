@@ -0,0 +1,486 @@
+package types
+
+import (
+	"encoding/json"
+	"math/bits"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CountryCodeSet is a set of country codes, the zero value is an empty set. Copies share their storage, use Clone to
+// modify a copy. It is marshalled to JSON as a sorted array.
+type CountryCodeSet struct {
+	set codeSet[CountryCode]
+}
+
+// CurrencySet is a set of currencies, the zero value is an empty set. Copies share their storage, use Clone to
+// modify a copy. It is marshalled to JSON as a sorted array.
+type CurrencySet struct {
+	set codeSet[Currency]
+}
+
+// LanguageSet is a set of languages, the zero value is an empty set. Copies share their storage, use Clone to
+// modify a copy. It is marshalled to JSON as a sorted array.
+type LanguageSet struct {
+	set codeSet[Language]
+}
+
+var (
+	countryCodeSpace = codeSpace[CountryCode]{index: countryCodeIndex, code: countryCodeFromIndex}
+	currencySpace    = codeSpace[Currency]{index: currencyIndex, code: currencyFromIndex}
+	languageSpace    = codeSpace[Language]{index: languageIndex, code: languageFromIndex}
+)
+
+// currencyIndexes assigns bit indexes to ISO 4217 and registered currencies on their first use in a set.
+var (
+	currencyIndexMu sync.RWMutex
+	currencyIndexes = make(map[Currency]int)
+	currencyCodes   []Currency
+)
+
+func NewCountryCodeSet(codes ...CountryCode) CountryCodeSet {
+	var s CountryCodeSet
+	s.Add(codes...)
+
+	return s
+}
+
+// Add adds the codes to the set in lower-case, empty codes are ignored.
+func (s *CountryCodeSet) Add(codes ...CountryCode) {
+	s.set.add(countryCodeSpace, codes)
+}
+
+func (s *CountryCodeSet) Remove(codes ...CountryCode) {
+	s.set.remove(countryCodeSpace, codes)
+}
+
+// Contains is case-insensitive, and does not allocate for lower-case codes, as returned by the constructors.
+func (s CountryCodeSet) Contains(code CountryCode) bool {
+	return s.set.contains(countryCodeSpace, code)
+}
+
+func (s CountryCodeSet) Len() int {
+	return s.set.len()
+}
+
+// Codes returns the codes of the set in ascending order.
+func (s CountryCodeSet) Codes() []CountryCode {
+	return s.set.codes(countryCodeSpace)
+}
+
+func (s CountryCodeSet) Clone() CountryCodeSet {
+	return CountryCodeSet{set: s.set.clone()}
+}
+
+func (s CountryCodeSet) Equal(other CountryCodeSet) bool {
+	return s.set.equal(countryCodeSpace, other.set)
+}
+
+func (s CountryCodeSet) Union(other CountryCodeSet) CountryCodeSet {
+	return CountryCodeSet{set: s.set.union(countryCodeSpace, other.set)}
+}
+
+func (s CountryCodeSet) Intersect(other CountryCodeSet) CountryCodeSet {
+	return CountryCodeSet{set: s.set.intersect(countryCodeSpace, other.set)}
+}
+
+// Difference returns the codes of s which are not in other.
+func (s CountryCodeSet) Difference(other CountryCodeSet) CountryCodeSet {
+	return CountryCodeSet{set: s.set.difference(countryCodeSpace, other.set)}
+}
+
+func (s CountryCodeSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Codes())
+}
+
+// UnmarshalJSON replaces the codes of the set, null leaves it unchanged.
+func (s *CountryCodeSet) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeSetJSON(&s.set, countryCodeSpace, b)
+}
+
+func NewCurrencySet(codes ...Currency) CurrencySet {
+	var s CurrencySet
+	s.Add(codes...)
+
+	return s
+}
+
+// Add adds the codes to the set in lower-case, empty codes are ignored.
+func (s *CurrencySet) Add(codes ...Currency) {
+	s.set.add(currencySpace, codes)
+}
+
+func (s *CurrencySet) Remove(codes ...Currency) {
+	s.set.remove(currencySpace, codes)
+}
+
+// Contains is case-insensitive, and does not allocate for lower-case codes, as returned by the constructors.
+func (s CurrencySet) Contains(code Currency) bool {
+	return s.set.contains(currencySpace, code)
+}
+
+func (s CurrencySet) Len() int {
+	return s.set.len()
+}
+
+// Codes returns the codes of the set in ascending order.
+func (s CurrencySet) Codes() []Currency {
+	return s.set.codes(currencySpace)
+}
+
+func (s CurrencySet) Clone() CurrencySet {
+	return CurrencySet{set: s.set.clone()}
+}
+
+func (s CurrencySet) Equal(other CurrencySet) bool {
+	return s.set.equal(currencySpace, other.set)
+}
+
+func (s CurrencySet) Union(other CurrencySet) CurrencySet {
+	return CurrencySet{set: s.set.union(currencySpace, other.set)}
+}
+
+func (s CurrencySet) Intersect(other CurrencySet) CurrencySet {
+	return CurrencySet{set: s.set.intersect(currencySpace, other.set)}
+}
+
+// Difference returns the codes of s which are not in other.
+func (s CurrencySet) Difference(other CurrencySet) CurrencySet {
+	return CurrencySet{set: s.set.difference(currencySpace, other.set)}
+}
+
+func (s CurrencySet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Codes())
+}
+
+// UnmarshalJSON replaces the codes of the set, null leaves it unchanged.
+func (s *CurrencySet) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeSetJSON(&s.set, currencySpace, b)
+}
+
+func NewLanguageSet(codes ...Language) LanguageSet {
+	var s LanguageSet
+	s.Add(codes...)
+
+	return s
+}
+
+// Add adds the codes to the set in lower-case, empty codes are ignored.
+func (s *LanguageSet) Add(codes ...Language) {
+	s.set.add(languageSpace, codes)
+}
+
+func (s *LanguageSet) Remove(codes ...Language) {
+	s.set.remove(languageSpace, codes)
+}
+
+// Contains is case-insensitive, and does not allocate for lower-case codes, as returned by the constructors.
+func (s LanguageSet) Contains(code Language) bool {
+	return s.set.contains(languageSpace, code)
+}
+
+func (s LanguageSet) Len() int {
+	return s.set.len()
+}
+
+// Codes returns the codes of the set in ascending order.
+func (s LanguageSet) Codes() []Language {
+	return s.set.codes(languageSpace)
+}
+
+func (s LanguageSet) Clone() LanguageSet {
+	return LanguageSet{set: s.set.clone()}
+}
+
+func (s LanguageSet) Equal(other LanguageSet) bool {
+	return s.set.equal(languageSpace, other.set)
+}
+
+func (s LanguageSet) Union(other LanguageSet) LanguageSet {
+	return LanguageSet{set: s.set.union(languageSpace, other.set)}
+}
+
+func (s LanguageSet) Intersect(other LanguageSet) LanguageSet {
+	return LanguageSet{set: s.set.intersect(languageSpace, other.set)}
+}
+
+// Difference returns the codes of s which are not in other.
+func (s LanguageSet) Difference(other LanguageSet) LanguageSet {
+	return LanguageSet{set: s.set.difference(languageSpace, other.set)}
+}
+
+func (s LanguageSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Codes())
+}
+
+// UnmarshalJSON replaces the codes of the set, null leaves it unchanged.
+func (s *LanguageSet) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeSetJSON(&s.set, languageSpace, b)
+}
+
+// codeSpace maps codes to bit indexes. index only assigns new indexes if assign is set, so lookups do not lock for
+// writing.
+type codeSpace[T ~string] struct {
+	index func(code T, assign bool) (int, bool)
+	code  func(index int) T
+}
+
+// codeSet stores codes with an index in a bitset, and the rest, eg. currencies registered after they were added, in
+// a map. A code is stored in one of them.
+type codeSet[T ~string] struct {
+	bits  []uint64
+	other map[T]struct{}
+}
+
+func (s *codeSet[T]) add(space codeSpace[T], codes []T) {
+	for _, code := range codes {
+		code = lowerCode(code)
+		if code == "" {
+			continue
+		}
+
+		i, ok := space.index(code, true)
+		if !ok {
+			if s.other == nil {
+				s.other = make(map[T]struct{})
+			}
+			s.other[code] = struct{}{}
+			continue
+		}
+
+		if w := i / 64; w >= len(s.bits) {
+			s.bits = append(s.bits, make([]uint64, w+1-len(s.bits))...)
+		}
+		s.bits[i/64] |= 1 << (i % 64)
+		delete(s.other, code)
+	}
+}
+
+func (s *codeSet[T]) remove(space codeSpace[T], codes []T) {
+	for _, code := range codes {
+		code = lowerCode(code)
+		if i, ok := space.index(code, false); ok && i/64 < len(s.bits) {
+			s.bits[i/64] &^= 1 << (i % 64)
+		}
+		delete(s.other, code)
+	}
+}
+
+func (s codeSet[T]) contains(space codeSpace[T], code T) bool {
+	code = lowerCode(code)
+	if i, ok := space.index(code, false); ok && i/64 < len(s.bits) && s.bits[i/64]&(1<<(i%64)) != 0 {
+		return true
+	}
+
+	_, ok := s.other[code]
+
+	return ok
+}
+
+func (s codeSet[T]) len() int {
+	n := len(s.other)
+	for _, w := range s.bits {
+		n += bits.OnesCount64(w)
+	}
+
+	return n
+}
+
+func (s codeSet[T]) codes(space codeSpace[T]) []T {
+	codes := make([]T, 0, s.len())
+	for w, word := range s.bits {
+		for ; word != 0; word &= word - 1 {
+			codes = append(codes, space.code(w*64+bits.TrailingZeros64(word)))
+		}
+	}
+	for code := range s.other {
+		codes = append(codes, code)
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+
+	return codes
+}
+
+func (s codeSet[T]) clone() codeSet[T] {
+	c := codeSet[T]{bits: append([]uint64(nil), s.bits...)}
+	for code := range s.other {
+		if c.other == nil {
+			c.other = make(map[T]struct{}, len(s.other))
+		}
+		c.other[code] = struct{}{}
+	}
+
+	return c
+}
+
+func (s codeSet[T]) equal(space codeSpace[T], other codeSet[T]) bool {
+	if s.len() != other.len() {
+		return false
+	}
+
+	for _, code := range s.codes(space) {
+		if !other.contains(space, code) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s codeSet[T]) union(space codeSpace[T], other codeSet[T]) codeSet[T] {
+	u := s.clone()
+	if len(other.bits) > len(u.bits) {
+		u.bits = append(u.bits, make([]uint64, len(other.bits)-len(u.bits))...)
+	}
+	for w, word := range other.bits {
+		u.bits[w] |= word
+	}
+
+	// codes of other may have an index assigned since they were added
+	u.add(space, mapKeys(u.other))
+	u.add(space, mapKeys(other.other))
+
+	return u
+}
+
+func (s codeSet[T]) intersect(space codeSpace[T], other codeSet[T]) codeSet[T] {
+	var i codeSet[T]
+	for w := 0; w < len(s.bits) && w < len(other.bits); w++ {
+		if word := s.bits[w] & other.bits[w]; word != 0 {
+			if w >= len(i.bits) {
+				i.bits = append(i.bits, make([]uint64, w+1-len(i.bits))...)
+			}
+			i.bits[w] = word
+		}
+	}
+
+	for code := range s.other {
+		if other.contains(space, code) {
+			i.add(space, []T{code})
+		}
+	}
+	for code := range other.other {
+		if s.contains(space, code) {
+			i.add(space, []T{code})
+		}
+	}
+
+	return i
+}
+
+func (s codeSet[T]) difference(space codeSpace[T], other codeSet[T]) codeSet[T] {
+	d := s.clone()
+	for w := 0; w < len(d.bits) && w < len(other.bits); w++ {
+		d.bits[w] &^= other.bits[w]
+	}
+
+	for code := range s.other {
+		if other.contains(space, code) {
+			delete(d.other, code)
+		}
+	}
+	d.remove(space, mapKeys(other.other))
+
+	return d
+}
+
+func unmarshalCodeSetJSON[T ~string](s *codeSet[T], space codeSpace[T], b []byte) error {
+	var codes []T
+	if err := json.Unmarshal(b, &codes); err != nil {
+		return err
+	}
+	if codes == nil {
+		return nil
+	}
+
+	*s = codeSet[T]{}
+	s.add(space, codes)
+
+	return nil
+}
+
+// lowerCode does not allocate for lower-case codes.
+func lowerCode[T ~string](code T) T {
+	return T(strings.ToLower(string(code)))
+}
+
+func mapKeys[T ~string](m map[T]struct{}) []T {
+	keys := make([]T, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// countryCodeIndex returns the index of the country among the two-letter codes, and 676 for t1.
+func countryCodeIndex(c CountryCode, _ bool) (int, bool) {
+	if c == "t1" {
+		return 26 * 26, true
+	}
+
+	n, ok := languageNumeric(Language(c))
+
+	return int(n), ok
+}
+
+func countryCodeFromIndex(i int) CountryCode {
+	if i == 26*26 {
+		return "t1"
+	}
+
+	l, _ := languageFromNumeric(uint64(i))
+
+	return CountryCode(l)
+}
+
+func languageIndex(l Language, _ bool) (int, bool) {
+	n, ok := languageNumeric(l)
+
+	return int(n), ok
+}
+
+func languageFromIndex(i int) Language {
+	l, _ := languageFromNumeric(uint64(i))
+
+	return l
+}
+
+// currencyIndex returns the index of an ISO 4217 or registered currency, it is assigned on first use if assign is set.
+func currencyIndex(c Currency, assign bool) (int, bool) {
+	currencyIndexMu.RLock()
+	i, ok := currencyIndexes[c]
+	currencyIndexMu.RUnlock()
+
+	if ok || !assign {
+		return i, ok
+	}
+
+	if _, ok := lookupISO4217(c); !ok {
+		if _, ok := lookupRegistry(c); !ok {
+			return 0, false
+		}
+	}
+
+	currencyIndexMu.Lock()
+	defer currencyIndexMu.Unlock()
+
+	if i, ok := currencyIndexes[c]; ok {
+		return i, true
+	}
+
+	i = len(currencyCodes)
+	currencyIndexes[c] = i
+	currencyCodes = append(currencyCodes, c)
+
+	return i, true
+}
+
+func currencyFromIndex(i int) Currency {
+	currencyIndexMu.RLock()
+	defer currencyIndexMu.RUnlock()
+
+	return currencyCodes[i]
+}
@@ -0,0 +1,174 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodeSetAlgebra(t *testing.T) {
+	for index, test := range []struct {
+		a, b                 CountryCodeSet
+		expectedUnion        []CountryCode
+		expectedIntersect    []CountryCode
+		expectedDifference   []CountryCode
+		expectedBADifference []CountryCode
+	}{
+		{
+			a:                    NewCountryCodeSet("hu", "de", "t1"),
+			b:                    NewCountryCodeSet("de", "zz", "aa"),
+			expectedUnion:        []CountryCode{"aa", "de", "hu", "t1", "zz"},
+			expectedIntersect:    []CountryCode{"de"},
+			expectedDifference:   []CountryCode{"hu", "t1"},
+			expectedBADifference: []CountryCode{"aa", "zz"},
+		},
+		{
+			a:                    NewCountryCodeSet("hu", ""),
+			b:                    CountryCodeSet{},
+			expectedUnion:        []CountryCode{"hu"},
+			expectedIntersect:    []CountryCode{},
+			expectedDifference:   []CountryCode{"hu"},
+			expectedBADifference: []CountryCode{},
+		},
+		{
+			a:                    NewCountryCodeSet("xx1", "hu"),
+			b:                    NewCountryCodeSet("xx1", "no"),
+			expectedUnion:        []CountryCode{"hu", "no", "xx1"},
+			expectedIntersect:    []CountryCode{"xx1"},
+			expectedDifference:   []CountryCode{"hu"},
+			expectedBADifference: []CountryCode{"no"},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v, %v -> %v", index+1, test.a.Codes(), test.b.Codes(), test.expectedUnion), func(t *testing.T) {
+			if result := test.a.Union(test.b).Codes(); !reflect.DeepEqual(result, test.expectedUnion) {
+				t.Errorf("union expected: %v, got: %v", test.expectedUnion, result)
+			}
+			if result := test.a.Intersect(test.b).Codes(); !reflect.DeepEqual(result, test.expectedIntersect) {
+				t.Errorf("intersect expected: %v, got: %v", test.expectedIntersect, result)
+			}
+			if result := test.a.Difference(test.b).Codes(); !reflect.DeepEqual(result, test.expectedDifference) {
+				t.Errorf("difference expected: %v, got: %v", test.expectedDifference, result)
+			}
+			if result := test.b.Difference(test.a).Codes(); !reflect.DeepEqual(result, test.expectedBADifference) {
+				t.Errorf("difference expected: %v, got: %v", test.expectedBADifference, result)
+			}
+		})
+	}
+}
+
+func TestCodeSetAddRemove(t *testing.T) {
+	s := NewLanguageSet("en", "hu")
+	c := s.Clone()
+	s.Add("de", "en")
+	s.Remove("hu", "fr")
+
+	if expected := []Language{"de", "en"}; !reflect.DeepEqual(s.Codes(), expected) || s.Len() != 2 {
+		t.Errorf("expected: %v, got: %v", expected, s.Codes())
+	}
+	if !s.Contains("de") || s.Contains("hu") || s.Contains("") {
+		t.Errorf("unexpected membership: %v", s.Codes())
+	}
+	if expected := NewLanguageSet("hu", "en"); !c.Equal(expected) || c.Equal(s) {
+		t.Errorf("expected: %v, got: %v", expected.Codes(), c.Codes())
+	}
+}
+
+func TestCodeSetCase(t *testing.T) {
+	countries := NewCountryCodeSet("DE", "t1")
+	if !countries.Contains("de") || !countries.Contains("T1") || !reflect.DeepEqual(countries.Codes(), []CountryCode{"de", "t1"}) {
+		t.Errorf("expected case-insensitive membership, got: %v", countries.Codes())
+	}
+
+	currencies := NewCurrencySet("EUR", "huf")
+	currencies.Remove("HUF")
+	if !currencies.Contains("Eur") || currencies.Contains("huf") || currencies.Len() != 1 {
+		t.Errorf("expected case-insensitive membership, got: %v", currencies.Codes())
+	}
+}
+
+func TestCurrencySetRegistered(t *testing.T) {
+	// added before it is registered, so it is stored without an index
+	before := NewCurrencySet("setx", "eur")
+	if _, err := RegisterCurrency(CurrencyDefinition{Code: "SETX", Precision: 2}); err != nil {
+		t.Fatal(err)
+	}
+	after := NewCurrencySet("setx", "usd")
+
+	if !before.Contains("setx") || !after.Contains("setx") || !before.Equal(NewCurrencySet("eur", "setx")) {
+		t.Fatalf("expected setx in both sets: %v, %v", before.Codes(), after.Codes())
+	}
+	if result, expected := before.Union(after), []Currency{"eur", "setx", "usd"}; !reflect.DeepEqual(result.Codes(), expected) || result.Len() != 3 {
+		t.Errorf("union expected: %v, got: %v", expected, result.Codes())
+	}
+	if result, expected := before.Intersect(after).Codes(), []Currency{"setx"}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("intersect expected: %v, got: %v", expected, result)
+	}
+	if result, expected := after.Difference(before).Codes(), []Currency{"usd"}; !reflect.DeepEqual(result, expected) {
+		t.Errorf("difference expected: %v, got: %v", expected, result)
+	}
+}
+
+func TestCodeSetContainsAllocs(t *testing.T) {
+	countries := NewCountryCodeSet("hu", "de")
+	currencies := NewCurrencySet("eur", "huf")
+	languages := NewLanguageSet("en")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		countries.Contains("hu")
+		currencies.Contains("huf")
+		currencies.Contains("usd")
+		languages.Contains("de")
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got: %v", allocs)
+	}
+}
+
+func TestCodeSetJSON(t *testing.T) {
+	for index, test := range []struct {
+		json          string
+		expectedValue CurrencySet
+		expectedJSON  string
+		expectedError string
+	}{
+		{json: `["USD","eur","huf","eur"]`, expectedValue: NewCurrencySet("eur", "huf", "usd"), expectedJSON: `["eur","huf","usd"]`},
+		{json: `[]`, expectedValue: CurrencySet{}, expectedJSON: `[]`},
+		{json: `null`, expectedValue: NewCurrencySet("gbp"), expectedJSON: `["gbp"]`},
+		{json: `["eur","euro"]`, expectedError: "invalid currency: euro"},
+		{json: `"eur"`, expectedError: "cannot unmarshal string"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.json, test.expectedJSON), func(t *testing.T) {
+			result := NewCurrencySet("gbp")
+			err := json.Unmarshal([]byte(test.json), &result)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+			if !result.Equal(test.expectedValue) {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue.Codes(), result.Codes())
+			}
+
+			b, err := json.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expectedJSON {
+				t.Fatalf("expected: %s, got: %s", test.expectedJSON, b)
+			}
+		})
+	}
+}
+
+func BenchmarkCurrencySetContains(b *testing.B) {
+	s := NewCurrencySet("eur", "huf", "usd")
+
+	for i := 0; i < b.N; i++ {
+		s.Contains("huf")
+	}
+}
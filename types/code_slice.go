@@ -0,0 +1,289 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// CountryCodes is stored as a PostgreSQL array, eg. text[], wrap it in Delimited for delimited strings.
+type CountryCodes []CountryCode
+
+// Currencies is stored as a PostgreSQL array, eg. text[], wrap it in Delimited for delimited strings.
+type Currencies []Currency
+
+// Languages is stored as a PostgreSQL array, eg. text[], wrap it in Delimited for delimited strings.
+type Languages []Language
+
+// CodeSlice is implemented by *CountryCodes, *Currencies and *Languages.
+type CodeSlice interface {
+	codeStrings() ([]string, bool)
+	setCodeStrings(codes []string) error
+}
+
+// Delimited stores a code slice as a delimited string, eg. "hu,de" in a MySQL varchar column. Codes is a pointer to
+// a code slice, Delimiter is a comma if empty, eg.
+//
+//	err := row.Scan(types.Delimited{Codes: &countries})
+//
+// A nil slice is stored as NULL, and the empty string is scanned to an empty slice.
+type Delimited struct {
+	Codes     CodeSlice
+	Delimiter string
+}
+
+func (d Delimited) Value() (driver.Value, error) {
+	if d.Codes == nil {
+		return nil, nil
+	}
+
+	codes, ok := d.Codes.codeStrings()
+	if !ok {
+		return nil, nil
+	}
+
+	return strings.Join(codes, d.delimiter()), nil
+}
+
+func (d Delimited) Scan(src interface{}) error {
+	if d.Codes == nil {
+		return fmt.Errorf("cannot scan to Delimited without Codes")
+	}
+
+	var str string
+	switch src := src.(type) {
+	case nil:
+		return d.Codes.setCodeStrings(nil)
+	case string:
+		str = src
+	case []byte:
+		str = string(src)
+	case sql.RawBytes:
+		str = string(src)
+	default:
+		return fmt.Errorf("cannot convert %T to Delimited", src)
+	}
+
+	codes := make([]string, 0)
+	if strings.TrimSpace(str) != "" {
+		for _, code := range strings.Split(str, d.delimiter()) {
+			codes = append(codes, strings.TrimSpace(code))
+		}
+	}
+
+	return d.Codes.setCodeStrings(codes)
+}
+
+func (d Delimited) delimiter() string {
+	if d.Delimiter == "" {
+		return ","
+	}
+
+	return d.Delimiter
+}
+
+func (c CountryCodes) Value() (driver.Value, error) {
+	return codeArrayValue(&c)
+}
+
+func (c *CountryCodes) Scan(src interface{}) error {
+	return scanCodeArray(c, src, "CountryCodes")
+}
+
+func (c *CountryCodes) codeStrings() ([]string, bool) {
+	return codeStrings(*c)
+}
+
+func (c *CountryCodes) setCodeStrings(codes []string) error {
+	return setCodeStrings((*[]CountryCode)(c), codes, NewCountryCode)
+}
+
+func (c Currencies) Value() (driver.Value, error) {
+	return codeArrayValue(&c)
+}
+
+func (c *Currencies) Scan(src interface{}) error {
+	return scanCodeArray(c, src, "Currencies")
+}
+
+func (c *Currencies) codeStrings() ([]string, bool) {
+	return codeStrings(*c)
+}
+
+func (c *Currencies) setCodeStrings(codes []string) error {
+	return setCodeStrings((*[]Currency)(c), codes, NewCurrency)
+}
+
+func (l Languages) Value() (driver.Value, error) {
+	return codeArrayValue(&l)
+}
+
+func (l *Languages) Scan(src interface{}) error {
+	return scanCodeArray(l, src, "Languages")
+}
+
+func (l *Languages) codeStrings() ([]string, bool) {
+	return codeStrings(*l)
+}
+
+func (l *Languages) setCodeStrings(codes []string) error {
+	return setCodeStrings((*[]Language)(l), codes, NewLanguage)
+}
+
+// codeStrings returns the codes in their output case, false for a nil slice.
+func codeStrings[T ~string](codes []T) ([]string, bool) {
+	if codes == nil {
+		return nil, false
+	}
+
+	strs := make([]string, len(codes))
+	for i, code := range codes {
+		strs[i] = string(withOutputCase(code))
+	}
+
+	return strs, true
+}
+
+// setCodeStrings parses all codes before setting them, nil sets a nil slice.
+func setCodeStrings[T ~string](c *[]T, strs []string, parse func(string) (T, error)) error {
+	if strs == nil {
+		*c = nil
+		return nil
+	}
+
+	codes := make([]T, len(strs))
+	for i, str := range strs {
+		code, err := parse(str)
+		if err != nil {
+			return err
+		}
+		codes[i] = code
+	}
+
+	*c = codes
+
+	return nil
+}
+
+// codeArrayValue returns a PostgreSQL array literal, eg. {hu,de}, empty codes are NULL elements.
+func codeArrayValue(c CodeSlice) (driver.Value, error) {
+	codes, ok := c.codeStrings()
+	if !ok {
+		return nil, nil
+	}
+
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, code := range codes {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(quoteArrayElement(code))
+	}
+	b.WriteByte('}')
+
+	return b.String(), nil
+}
+
+// scanCodeArray scans a PostgreSQL array literal, typeName is used in the error of unsupported sources.
+func scanCodeArray(c CodeSlice, src interface{}, typeName string) error {
+	var str string
+	switch src := src.(type) {
+	case nil:
+		return c.setCodeStrings(nil)
+	case string:
+		str = src
+	case []byte:
+		str = string(src)
+	case sql.RawBytes:
+		str = string(src)
+	default:
+		return fmt.Errorf("cannot convert %T to %s", src, typeName)
+	}
+
+	codes, err := splitArrayLiteral(str)
+	if err != nil {
+		return err
+	}
+
+	return c.setCodeStrings(codes)
+}
+
+func quoteArrayElement(s string) string {
+	if s == "" {
+		return "NULL"
+	}
+
+	if !strings.EqualFold(s, "NULL") && !strings.ContainsAny(s, "{}\",\\ \t\n\r\v\f") {
+		return s
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// splitArrayLiteral returns the elements of a one-dimensional PostgreSQL array literal, NULL elements are empty.
+func splitArrayLiteral(s string) ([]string, error) {
+	str := strings.TrimSpace(s)
+	if len(str) < 2 || str[0] != '{' || str[len(str)-1] != '}' {
+		return nil, fmt.Errorf("invalid array: %s", s)
+	}
+
+	body := str[1 : len(str)-1]
+	elements := make([]string, 0)
+	if strings.TrimSpace(body) == "" {
+		return elements, nil
+	}
+
+	for i := 0; ; i++ {
+		i = skipArraySpace(body, i)
+
+		if i < len(body) && body[i] == '"' {
+			var element strings.Builder
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				element.WriteByte(body[i])
+			}
+			if i == len(body) {
+				return nil, fmt.Errorf("invalid array: %s", s)
+			}
+
+			i = skipArraySpace(body, i+1)
+			elements = append(elements, element.String())
+		} else {
+			start := i
+			for i < len(body) && body[i] != ',' {
+				if body[i] == '{' || body[i] == '}' || body[i] == '"' || body[i] == '\\' {
+					return nil, fmt.Errorf("invalid array: %s", s)
+				}
+				i++
+			}
+
+			element := strings.TrimSpace(body[start:i])
+			if element == "" {
+				return nil, fmt.Errorf("invalid array: %s", s)
+			}
+			if strings.EqualFold(element, "NULL") {
+				element = ""
+			}
+			elements = append(elements, element)
+		}
+
+		if i == len(body) {
+			return elements, nil
+		}
+		if body[i] != ',' {
+			return nil, fmt.Errorf("invalid array: %s", s)
+		}
+	}
+}
+
+func skipArraySpace(s string, i int) int {
+	for i < len(s) && strings.IndexByte(" \t\n\r\v\f", s[i]) >= 0 {
+		i++
+	}
+
+	return i
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCodeSliceValue(t *testing.T) {
+	for index, test := range []struct {
+		value         driver.Valuer
+		expectedValue driver.Value
+	}{
+		{value: CountryCodes{"hu", "de"}, expectedValue: "{hu,de}"},
+		{value: Currencies{"eur", ""}, expectedValue: "{eur,NULL}"},
+		{value: Languages{}, expectedValue: "{}"},
+		{value: Languages(nil), expectedValue: nil},
+		{value: Delimited{Codes: &CountryCodes{"hu", "de"}}, expectedValue: "hu,de"},
+		{value: Delimited{Codes: &Currencies{"eur", "huf"}, Delimiter: ";"}, expectedValue: "eur;huf"},
+		{value: Delimited{Codes: &Languages{}}, expectedValue: ""},
+		{value: Delimited{Codes: new(Languages)}, expectedValue: nil},
+		{value: Delimited{}, expectedValue: nil},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.value, test.expectedValue), func(t *testing.T) {
+			result, err := test.value.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != test.expectedValue {
+				t.Fatalf("expected: %#v, got: %#v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCodeSliceValueOutputCase(t *testing.T) {
+	defer SetOutputCase[Currency](LowerCase)
+	SetOutputCase[Currency](UpperCase)
+
+	result, err := Currencies{"eur", "usd"}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "{EUR,USD}" {
+		t.Fatalf("expected: {EUR,USD}, got: %v", result)
+	}
+}
+
+func TestCodeSliceScan(t *testing.T) {
+	for index, test := range []struct {
+		src           interface{}
+		expectedValue CountryCodes
+		expectedError string
+	}{
+		{src: "{hu,DE}", expectedValue: CountryCodes{"hu", "de"}},
+		{src: []byte(`{ "hu" , no ,NULL}`), expectedValue: CountryCodes{"hu", "no", ""}},
+		{src: sql.RawBytes("{}"), expectedValue: CountryCodes{}},
+		{src: nil, expectedValue: nil},
+		{src: "{hu,HUN}", expectedError: "invalid country code: HUN"},
+		{src: "{hu,}", expectedError: "invalid array: {hu,}"},
+		{src: `{"hu}`, expectedError: `invalid array: {"hu}`},
+		{src: "{{hu},{de}}", expectedError: "invalid array: {{hu},{de}}"},
+		{src: "hu,de", expectedError: "invalid array: hu,de"},
+		{src: 1, expectedError: "cannot convert int to CountryCodes"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			var result CountryCodes
+			err := result.Scan(test.src)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+			if !reflect.DeepEqual(result, test.expectedValue) {
+				t.Fatalf("expected: %#v, got: %#v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDelimitedScan(t *testing.T) {
+	for index, test := range []struct {
+		src           interface{}
+		delimiter     string
+		expectedValue Currencies
+		expectedError string
+	}{
+		{src: "eur,HUF", expectedValue: Currencies{"eur", "huf"}},
+		{src: []byte("eur; usd"), delimiter: ";", expectedValue: Currencies{"eur", "usd"}},
+		{src: sql.RawBytes(" "), expectedValue: Currencies{}},
+		{src: nil, expectedValue: nil},
+		{src: "eur,euro", expectedError: "invalid currency: euro"},
+		{src: 1.5, expectedError: "cannot convert float64 to Delimited"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			result := Currencies{"gbp"}
+			err := Delimited{Codes: &result, Delimiter: test.delimiter}.Scan(test.src)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+			if !reflect.DeepEqual(result, test.expectedValue) {
+				t.Fatalf("expected: %#v, got: %#v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestSplitArrayLiteral(t *testing.T) {
+	for index, test := range []string{"NULL", "null", "a b", `a"b`, `a\b`, "{}", ",", "Europe/Budapest"} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test), func(t *testing.T) {
+			elements, err := splitArrayLiteral("{" + quoteArrayElement(test) + ",x}")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := []string{test, "x"}; !reflect.DeepEqual(elements, expected) {
+				t.Fatalf("expected: %q, got: %q", expected, elements)
+			}
+		})
+	}
+}
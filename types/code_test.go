@@ -0,0 +1,109 @@
+package types
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types/codetest"
+)
+
+var airportCodeValidator = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
+type airportCodeSpec struct{}
+
+func (airportCodeSpec) CodeName() string {
+	return "airport code"
+}
+
+func (airportCodeSpec) ValidCode(code string) bool {
+	return airportCodeValidator.MatchString(code)
+}
+
+type airportCode = Code[airportCodeSpec]
+
+func TestCode(t *testing.T) {
+	codetest.Run(t, NewCode[airportCodeSpec], []codetest.Case{
+		{
+			Text:          "",
+			ExpectedValue: "",
+		},
+		{
+			Text:          "bud",
+			ExpectedValue: "bud",
+		},
+		{
+			Text:          "BUD",
+			ExpectedValue: "bud",
+		},
+		{
+			Text:          "LHBP",
+			ExpectedError: "invalid airport code: LHBP",
+		},
+		{
+			Text:          "b1d",
+			ExpectedError: "invalid airport code",
+		},
+	})
+}
+
+func TestCodeScanError(t *testing.T) {
+	var code airportCode
+	if err := code.Scan(1.5); err == nil || !strings.Contains(err.Error(), "cannot convert float64 to airport code") {
+		t.Fatalf("expected error: cannot convert float64 to airport code, got: %v", err)
+	}
+}
+
+func TestSetOutputCase(t *testing.T) {
+	SetOutputCase[Currency](UpperCase)
+	SetOutputCase[airportCode](UpperCase)
+	defer SetOutputCase[Currency](LowerCase)
+	defer SetOutputCase[airportCode](LowerCase)
+
+	b, err := json.Marshal(map[string]interface{}{
+		"currency": Currency("eur"),
+		"country":  CountryCode("de"),
+		"airport":  airportCode("bud"),
+		"money":    Money{Amount: MustDecimal("1.50"), Currency: "eur"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"airport":"BUD","country":"de","currency":"EUR","money":{"amount":"1.50","currency":"EUR"}}`; string(b) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, string(b))
+	}
+
+	text, err := Currency("eur").MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "EUR" {
+		t.Fatalf("expected: EUR, got: %s", text)
+	}
+
+	value, err := Currency("eur").Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "EUR" {
+		t.Fatalf("expected: EUR, got: %v", value)
+	}
+
+	if s := Currency("eur").String(); s != "eur" {
+		t.Fatalf("expected: eur, got: %v", s)
+	}
+
+	var currency Currency
+	if err := json.Unmarshal([]byte(`"EUR"`), &currency); err != nil {
+		t.Fatal(err)
+	}
+	if currency != "eur" {
+		t.Fatalf("expected: eur, got: %v", currency)
+	}
+
+	SetOutputCase[Currency](LowerCase)
+	if b, _ := json.Marshal(Currency("eur")); string(b) != `"eur"` {
+		t.Fatalf("expected: \"eur\", got: %s", b)
+	}
+}
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"reflect"
+
+	"github.com/ugorji/go/codec"
+)
+
+// RegisterMsgpackExt registers CountryCode, Currency and Language on h as msgpack extensions with the tags tag,
+// tag+1 and tag+2. They are encoded in the compact form of SetBinaryCompact: 3 bytes for codes with a number (the
+// ISO 3166-1 and ISO 4217 numeric codes, and the index of the language among the two-letter codes), text otherwise,
+// eg. for registered currencies or T1. Empty codes are encoded as nil. Set h.WriteExt, otherwise the extensions are
+// written as msgpack strings.
+//
+// Decoding accepts the extensions and strings, so register it on decoders before encoders. Other handles, eg.
+// codec.JsonHandle, are not affected.
+func RegisterMsgpackExt(h *codec.MsgpackHandle, tag uint64) error {
+	if err := h.SetBytesExt(reflect.TypeOf(CountryCode("")), tag, codeExt[CountryCode]{
+		kind:        "country code",
+		parse:       NewCountryCode,
+		numeric:     countryCodeNumeric,
+		fromNumeric: countryCodeFromNumeric,
+	}); err != nil {
+		return err
+	}
+
+	if err := h.SetBytesExt(reflect.TypeOf(Currency("")), tag+1, codeExt[Currency]{
+		kind:        "currency",
+		parse:       NewCurrency,
+		numeric:     currencyNumeric,
+		fromNumeric: currencyFromNumeric,
+	}); err != nil {
+		return err
+	}
+
+	return h.SetBytesExt(reflect.TypeOf(Language("")), tag+2, codeExt[Language]{
+		kind:        "language",
+		parse:       NewLanguage,
+		numeric:     languageNumeric,
+		fromNumeric: languageFromNumeric,
+	})
+}
+
+// codeExt is the codec.BytesExt of a code type.
+type codeExt[T ~string] struct {
+	kind        string
+	parse       func(string) (T, error)
+	numeric     func(T) (uint64, bool)
+	fromNumeric func(uint64) (T, bool)
+}
+
+func (x codeExt[T]) WriteExt(v interface{}) []byte {
+	var c T
+	switch v := v.(type) {
+	case T:
+		c = v
+	case *T:
+		c = *v
+	}
+
+	if c == "" {
+		return nil
+	}
+
+	return compactCodeBinary(c, x.numeric)
+}
+
+// ReadExt panics on errors, which codec returns from Decode.
+func (x codeExt[T]) ReadExt(dst interface{}, src []byte) {
+	if err := unmarshalCodeBinary(dst.(*T), src, x.kind, x.parse, x.fromNumeric); err != nil {
+		panic(err)
+	}
+}
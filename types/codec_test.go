@@ -0,0 +1,251 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+const testMsgpackExtTag = 10
+
+type codecRecord struct {
+	Country  CountryCode
+	Currency Currency
+	Language Language
+}
+
+func newMsgpackExtHandle(t testing.TB) *codec.MsgpackHandle {
+	t.Helper()
+
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	if err := RegisterMsgpackExt(handle, testMsgpackExtTag); err != nil {
+		t.Fatal(err)
+	}
+
+	return handle
+}
+
+func TestMsgpackExt(t *testing.T) {
+	RegisterCryptoCurrencies()
+
+	for index, test := range []struct {
+		value         interface{}
+		expectedValue string
+	}{
+		{
+			// ext 8, length 3, tag 10, numeric 348
+			value:         CountryCode("hu"),
+			expectedValue: "c7030a01015c",
+		},
+		{
+			value:         CountryCode("t1"),
+			expectedValue: "d50a7431",
+		},
+		{
+			value:         Currency("eur"),
+			expectedValue: "c7030b0103d2",
+		},
+		{
+			value:         Currency("btc"),
+			expectedValue: "c7030b627463",
+		},
+		{
+			value:         Language("hu"),
+			expectedValue: "c7030c0100ca",
+		},
+		{
+			value:         Currency(""),
+			expectedValue: "c0",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.value, test.expectedValue), func(t *testing.T) {
+			handle := newMsgpackExtHandle(t)
+
+			var b []byte
+			if err := codec.NewEncoderBytes(&b, handle).Encode(test.value); err != nil {
+				t.Fatal(err)
+			}
+			if result := hex.EncodeToString(b); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestMsgpackExtRecord(t *testing.T) {
+	record := codecRecord{Country: "de", Currency: "huf", Language: "en"}
+
+	for index, encodeHandle := range []*codec.MsgpackHandle{newMsgpackExtHandle(t), {}} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, record), func(t *testing.T) {
+			var b []byte
+			if err := codec.NewEncoderBytes(&b, encodeHandle).Encode(record); err != nil {
+				t.Fatal(err)
+			}
+
+			// the extension handle decodes strings too
+			var result codecRecord
+			if err := codec.NewDecoderBytes(b, newMsgpackExtHandle(t)).Decode(&result); err != nil {
+				t.Fatal(err)
+			}
+			if result != record {
+				t.Fatalf("expected: %v, got: %v", record, result)
+			}
+		})
+	}
+}
+
+func TestMsgpackExtDecode(t *testing.T) {
+	for index, test := range []struct {
+		encoded       interface{}
+		into          interface{ String() string }
+		expectedValue string
+		expectedError string
+	}{
+		{
+			encoded:       "EUR",
+			into:          new(Currency),
+			expectedValue: "eur",
+		},
+		{
+			encoded:       []byte("hu"),
+			into:          new(CountryCode),
+			expectedValue: "hu",
+		},
+		{
+			encoded:       codec.RawExt{Tag: testMsgpackExtTag, Data: []byte{binaryTagNumeric, 0, 4}},
+			into:          new(CountryCode),
+			expectedValue: "af",
+		},
+		{
+			encoded:       codec.RawExt{Tag: testMsgpackExtTag + 1, Data: []byte{binaryTagNumeric, 1, 20}},
+			into:          new(Currency),
+			expectedValue: "dem",
+		},
+		{
+			encoded:       codec.RawExt{Tag: testMsgpackExtTag + 2, Data: []byte{binaryTagNumeric, 0, 0}},
+			into:          new(Language),
+			expectedValue: "aa",
+		},
+		{
+			encoded:       nil,
+			into:          new(Language),
+			expectedValue: "",
+		},
+		{
+			encoded:       codec.RawExt{Tag: testMsgpackExtTag + 1, Data: []byte{binaryTagNumeric, 3, 232}},
+			into:          new(Currency),
+			expectedError: "invalid currency: 1000: unknown code",
+		},
+		{
+			encoded:       codec.RawExt{Tag: testMsgpackExtTag + 2, Data: []byte{binaryTagNumeric, 2, 164}},
+			into:          new(Language),
+			expectedError: "invalid language: 676: unknown code",
+		},
+		{
+			encoded:       codec.RawExt{Tag: testMsgpackExtTag, Data: []byte("hu")},
+			into:          new(Currency),
+			expectedError: "wrong extension tag",
+		},
+		{
+			encoded:       "Foo",
+			into:          new(CountryCode),
+			expectedError: "invalid country code",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.encoded, test.expectedValue), func(t *testing.T) {
+			var b []byte
+			if err := codec.NewEncoderBytes(&b, &codec.MsgpackHandle{WriteExt: true}).Encode(test.encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			err := codec.NewDecoderBytes(b, newMsgpackExtHandle(t)).Decode(test.into)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result := test.into.String(); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCodecJSON(t *testing.T) {
+	for index, test := range []struct {
+		value         codecRecord
+		expectedValue string
+	}{
+		{
+			value:         codecRecord{Country: "hu", Currency: "eur", Language: "en"},
+			expectedValue: `{"Country":"hu","Currency":"eur","Language":"en"}`,
+		},
+		{
+			value:         codecRecord{},
+			expectedValue: `{"Country":null,"Currency":null,"Language":null}`,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.value, test.expectedValue), func(t *testing.T) {
+			var b []byte
+			if err := codec.NewEncoderBytes(&b, &codec.JsonHandle{}).Encode(test.value); err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expectedValue {
+				t.Fatalf("expected: %v, got: %s", test.expectedValue, b)
+			}
+		})
+	}
+}
+
+func BenchmarkCodec(b *testing.B) {
+	record := codecRecord{Country: "de", Currency: "eur", Language: "de"}
+
+	for _, ext := range []bool{false, true} {
+		name := "string"
+		handle := &codec.MsgpackHandle{}
+		if ext {
+			name = "ext"
+			handle = newMsgpackExtHandle(b)
+		}
+
+		b.Run(name+"/encode", func(b *testing.B) {
+			var buf []byte
+			encoder := codec.NewEncoderBytes(&buf, handle)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				buf = buf[:0]
+				encoder.ResetBytes(&buf)
+				if err := encoder.Encode(record); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(buf)), "bytes")
+		})
+
+		b.Run(name+"/decode", func(b *testing.B) {
+			var buf []byte
+			if err := codec.NewEncoderBytes(&buf, handle).Encode(record); err != nil {
+				b.Fatal(err)
+			}
+			decoder := codec.NewDecoderBytes(buf, handle)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				decoder.ResetBytes(buf)
+				var result codecRecord
+				if err := decoder.Decode(&result); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Package codetest is the shared test suite of string code types, like types.CountryCode or types.Code.
+package codetest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+	"gopkg.in/yaml.v3"
+)
+
+// Code is implemented by code types.
+type Code interface {
+	~string
+	fmt.Stringer
+	encoding.TextMarshaler
+	encoding.BinaryMarshaler
+	json.Marshaler
+	yaml.Marshaler
+	xml.Marshaler
+	xml.MarshalerAttr
+	driver.Valuer
+}
+
+// CodePtr is implemented by pointers to code types.
+type CodePtr[T Code] interface {
+	*T
+	encoding.TextUnmarshaler
+	encoding.BinaryUnmarshaler
+	json.Unmarshaler
+	yaml.Unmarshaler
+	xml.Unmarshaler
+	xml.UnmarshalerAttr
+	sql.Scanner
+}
+
+type xmlDocument[T any] struct {
+	XMLName xml.Name `xml:"doc"`
+	Attr    T        `xml:"attr,attr"`
+	Elem    T        `xml:"elem"`
+}
+
+// Case is an input of the code constructor, with the lower-case code or the error it must result in.
+type Case struct {
+	Text          string
+	ExpectedValue string
+	ExpectedError string
+}
+
+// Run tests the constructor, String, MsgPack, JSON, YAML, XML, Binary and SQL handling of a code type with the cases.
+func Run[T Code, P CodePtr[T]](t *testing.T, newCode func(string) (T, error), cases []Case) {
+	t.Run("New", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			code, err := newCode(test.Text)
+
+			return code.String(), err
+		})
+	})
+
+	t.Run("String", func(t *testing.T) {
+		for index, test := range cases {
+			t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.Text, test.Text), func(t *testing.T) {
+				if result := T(test.Text).String(); result != test.Text {
+					t.Fatalf("expected: %v, got: %v", test.Text, result)
+				}
+			})
+		}
+	})
+
+	t.Run("MsgPack", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			handle := &codec.MsgpackHandle{}
+
+			var textB []byte
+			if err := codec.NewEncoderBytes(&textB, handle).Encode(test.Text); err != nil {
+				t.Fatal(err)
+			}
+
+			var code T
+			if err := codec.NewDecoderBytes(textB, handle).Decode(P(&code)); err != nil {
+				return "", err
+			}
+
+			var b []byte
+			if err := codec.NewEncoderBytes(&b, handle).Encode(P(&code)); err != nil {
+				t.Fatal(err)
+			}
+
+			var str string
+			if err := codec.NewDecoderBytes(b, handle).Decode(&str); err != nil {
+				t.Fatal(err)
+			}
+
+			return str, nil
+		})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			textB, err := json.Marshal(test.Text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var code T
+			if err := json.Unmarshal(textB, P(&code)); err != nil {
+				return "", err
+			}
+
+			b, err := json.Marshal(code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.ExpectedValue == "" && string(b) != "null" {
+				t.Fatalf("expected: null, got: %s", b)
+			}
+
+			var str string
+			if err := json.Unmarshal(b, &str); err != nil {
+				t.Fatal(err)
+			}
+
+			return str, nil
+		})
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			textB, err := yaml.Marshal(test.Text)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var code T
+			if err := yaml.Unmarshal(textB, P(&code)); err != nil {
+				return "", err
+			}
+
+			b, err := yaml.Marshal(code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.ExpectedValue == "" && string(b) != "null\n" {
+				t.Fatalf("expected: null, got: %s", b)
+			}
+
+			var str string
+			if err := yaml.Unmarshal(b, &str); err != nil {
+				t.Fatal(err)
+			}
+
+			return str, nil
+		})
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			textB, err := xml.Marshal(xmlDocument[string]{Attr: test.Text, Elem: test.Text})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var doc xmlDocument[T]
+			if err := xml.Unmarshal(textB, &doc); err != nil {
+				return "", err
+			}
+
+			b, err := xml.Marshal(doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.ExpectedValue == "" && string(b) != "<doc></doc>" {
+				t.Fatalf("expected: <doc></doc>, got: %s", b)
+			}
+
+			var str xmlDocument[string]
+			if err := xml.Unmarshal(b, &str); err != nil {
+				t.Fatal(err)
+			}
+			if str.Attr != str.Elem {
+				t.Fatalf("expected attribute: %v, got: %v", str.Elem, str.Attr)
+			}
+
+			return str.Elem, nil
+		})
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			var code T
+			if err := P(&code).UnmarshalBinary([]byte(test.Text)); err != nil {
+				return "", err
+			}
+
+			b, err := code.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return string(b), nil
+		})
+	})
+
+	t.Run("Sql", func(t *testing.T) {
+		run(t, cases, func(t *testing.T, test Case) (string, error) {
+			origCode, err := newCode(test.Text)
+			if err != nil {
+				return "", err
+			}
+
+			driverValue, err := origCode.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s, ok := driverValue.(string)
+			if !ok && test.Text != "" {
+				t.Fatalf("value does not returned with a string, returned: %T", driverValue)
+			}
+
+			var scanValue T
+			if s == "" {
+				err = P(&scanValue).Scan(nil)
+			} else {
+				err = P(&scanValue).Scan(s)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, src := range []interface{}{[]byte(s), sql.RawBytes(s)} {
+				var bytesValue T
+				if err := P(&bytesValue).Scan(src); err != nil {
+					t.Fatal(err)
+				}
+				if bytesValue != scanValue {
+					t.Fatalf("expected scan of %T: %v, got: %v", src, scanValue, bytesValue)
+				}
+			}
+
+			return scanValue.String(), nil
+		})
+
+		var scanValue T
+		if err := P(&scanValue).Scan(1); err == nil || !strings.Contains(err.Error(), "cannot convert int to") {
+			t.Errorf("expected error: cannot convert int to, got: %v", err)
+		}
+	})
+}
+
+func run(t *testing.T, cases []Case, do func(t *testing.T, test Case) (string, error)) {
+	for index, test := range cases {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.Text, test.ExpectedValue), func(t *testing.T) {
+			result, err := do(t, test)
+			if err != nil {
+				if test.ExpectedError != "" && strings.Contains(err.Error(), test.ExpectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.ExpectedError != "" {
+				t.Errorf("expected error: %s, got none", test.ExpectedError)
+			}
+			if result != test.ExpectedValue {
+				t.Fatalf("expected: %v, got: %v", test.ExpectedValue, result)
+			}
+		})
+	}
+}
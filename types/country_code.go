@@ -1,106 +1,160 @@
 package types
 
 import (
-	"bytes"
 	"database/sql/driver"
-	"fmt"
+	"encoding/xml"
 	"regexp"
-	"strconv"
 	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
 )
 
 var countryCodeValidator = regexp.MustCompile(`(^[A-Za-z]{2}$)|(^[tT]1$)`)
 
+const (
+	regionalIndicatorA = '\U0001F1E6'
+	regionalIndicatorZ = '\U0001F1FF'
+	wavingBlackFlag    = '\U0001F3F4'
+	tagLatinSmallA     = '\U000E0061'
+	tagLatinSmallZ     = '\U000E007A'
+	variationSelector  = '\uFE0F'
+)
+
 // ISO 3166-1 Alpha-2 representation of country codes. T1 represents tor exit node
 type CountryCode string
 
-func NewCountryCode(code string) (CountryCode, error) {
-	if code == "" {
-		return "", nil
-	}
+type countryCodeSpec struct{}
 
-	if !countryCodeValidator.MatchString(code) {
-		return "", fmt.Errorf("invalid country code: %s", code)
-	}
+func (countryCodeSpec) CodeName() string {
+	return "country code"
+}
 
-	return CountryCode(strings.ToLower(code)), nil
+func (countryCodeSpec) ValidCode(code string) bool {
+	return countryCodeValidator.MatchString(code)
+}
+
+func NewCountryCode(code string) (CountryCode, error) {
+	return parseCode[CountryCode](countryCodeSpec{}, code)
 }
 
 func (c CountryCode) String() string {
 	return string(c)
 }
 
+// Upper returns the upper-case code, eg. "DE".
+func (c CountryCode) Upper() string {
+	return strings.ToUpper(string(c))
+}
+
 func (c CountryCode) MarshalText() ([]byte, error) {
-	return []byte(c.String()), nil
+	return marshalCodeText(withOutputCase(c))
 }
 
 func (c *CountryCode) UnmarshalText(b []byte) error {
-	code, err := NewCountryCode(string(b))
-	if err != nil {
-		return err
-	}
-
-	*c = code
-
-	return nil
+	return unmarshalCodeText(c, b, NewCountryCode)
 }
 
 func (c CountryCode) MarshalJSON() ([]byte, error) {
-	if c.String() == "" {
-		return []byte("null"), nil
-	}
-
-	return []byte(strconv.Quote(c.String())), nil
+	return marshalCodeJSON(withOutputCase(c))
 }
 
 func (c *CountryCode) UnmarshalJSON(b []byte) error {
-	if bytes.Equal(b, []byte("null")) {
-		return nil
-	}
+	return unmarshalCodeJSON(c, b, NewCountryCode)
+}
 
-	str, err := strconv.Unquote(string(b))
-	if err != nil {
-		return err
-	}
+func (c CountryCode) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(withOutputCase(c))
+}
 
-	code, err := NewCountryCode(str)
-	if err != nil {
-		return err
-	}
+func (c *CountryCode) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(c, node, NewCountryCode)
+}
+
+func (c CountryCode) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(withXMLOutputCase(c), e, start)
+}
 
-	*c = code
+func (c *CountryCode) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(c, d, start, NewCountryCode)
+}
 
-	return nil
+func (c CountryCode) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(withXMLOutputCase(c), name)
 }
 
+func (c *CountryCode) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(c, attr, NewCountryCode)
+}
+
+// MarshalBinary returns the text form, or the compact form if enabled by SetBinaryCompact.
 func (c CountryCode) MarshalBinary() ([]byte, error) {
-	return c.MarshalText()
+	return marshalCodeBinary(c, countryCodeNumeric)
 }
 
+// UnmarshalBinary accepts both the text and the compact form.
 func (c *CountryCode) UnmarshalBinary(b []byte) error {
-	return c.UnmarshalText(b)
+	return unmarshalCodeBinary(c, b, "country code", NewCountryCode, countryCodeFromNumeric)
 }
 
 func (c CountryCode) Value() (driver.Value, error) {
-	if c.String() == "" {
-		return nil, nil
+	return codeValue(withOutputCase(c))
+}
+
+func (c *CountryCode) Scan(src interface{}) error {
+	return scanCode(c, src, NewCountryCode, "CountryCode")
+}
+
+// Emoji returns the flag of the country as a pair of regional indicator symbols.
+// Codes that have no flag (empty, T1) return an empty string, unassigned codes
+// are displayed as the two letters by most platforms.
+func (c CountryCode) Emoji() string {
+	if len(c) != 2 {
+		return ""
 	}
 
-	return c.String(), nil
+	code := strings.ToLower(c.String())
+
+	var b strings.Builder
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'a' || code[i] > 'z' {
+			return ""
+		}
+		b.WriteRune(regionalIndicatorA + rune(code[i]-'a'))
+	}
+
+	return b.String()
 }
 
-func (c *CountryCode) Scan(src interface{}) error {
-	if src == nil {
-		*c = ""
-		return nil
+// NewCountryCodeFromEmoji parses a flag emoji made of two regional indicator symbols.
+// Subdivision flags (eg. England) are rejected with ErrUnknownCode, as they have no two-letter code.
+func NewCountryCodeFromEmoji(emoji string) (CountryCode, error) {
+	if emoji == "" {
+		return "", nil
 	}
 
-	if src, ok := src.(string); ok {
-		var err error
-		*c, err = NewCountryCode(src)
+	flag := strings.TrimRight(emoji, string(variationSelector))
+
+	first, size := utf8.DecodeRuneInString(flag)
+	if first == wavingBlackFlag && size < len(flag) {
+		tag, _ := utf8.DecodeRuneInString(flag[size:])
+		if tag >= tagLatinSmallA && tag <= tagLatinSmallZ {
+			return "", &InvalidCodeError{Kind: "country code", Input: emoji, Reason: ErrUnknownCode}
+		}
+	}
+
+	runes := []rune(flag)
+	if len(runes) != 2 {
+		return "", &InvalidCodeError{Kind: "country code", Input: emoji, Reason: ErrInvalidFormat}
+	}
 
-		return err
+	code := make([]byte, 0, 2)
+	for _, r := range runes {
+		if r < regionalIndicatorA || r > regionalIndicatorZ {
+			return "", &InvalidCodeError{Kind: "country code", Input: emoji, Reason: ErrInvalidFormat}
+		}
+		code = append(code, byte('a'+r-regionalIndicatorA))
 	}
 
-	return fmt.Errorf("cannot convert %T to CountryCode", src)
+	return NewCountryCode(string(code))
 }
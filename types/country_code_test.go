@@ -1,242 +1,122 @@
 package types
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
-	"github.com/ugorji/go/codec"
+	"github.com/proemergotech/types/types/codetest"
 )
 
-func TestCountryCodeNew(t *testing.T) {
-	for index, test := range []struct {
-		text          string
-		expectedValue CountryCode
-		expectedError string
-	}{
+func TestCountryCode(t *testing.T) {
+	codetest.Run(t, NewCountryCode, []codetest.Case{
 		{
-			text:          "",
-			expectedValue: "",
+			Text:          "",
+			ExpectedValue: "",
 		},
 		{
-			text:          "fo",
-			expectedValue: "fo",
+			Text:          "fo",
+			ExpectedValue: "fo",
 		},
 		{
-			text:          "Fo",
-			expectedValue: "fo",
+			Text:          "Fo",
+			ExpectedValue: "fo",
 		},
 		{
-			text:          "t1",
-			expectedValue: "t1",
+			Text:          "t1",
+			ExpectedValue: "t1",
 		},
 		{
-			text:          "Foo",
-			expectedError: "invalid country code",
+			Text:          "T1",
+			ExpectedValue: "t1",
 		},
 		{
-			text:          "12",
-			expectedError: "invalid country code",
+			Text:          "Foo",
+			ExpectedError: "invalid country code",
 		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			result, err := NewCountryCode(test.text)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-			if !strings.EqualFold(result.String(), test.expectedValue.String()) {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
-			}
-		})
-	}
-}
-
-func TestCountryCodeString(t *testing.T) {
-	for index, test := range []struct {
-		code          CountryCode
-		expectedValue string
-	}{
 		{
-			code:          "",
-			expectedValue: "",
+			Text:          "12",
+			ExpectedError: "invalid country code",
 		},
-		{
-			code:          "foo",
-			expectedValue: "foo",
-		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.code, test.expectedValue), func(t *testing.T) {
-			result := test.code.String()
-			if result != test.expectedValue {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
-			}
-		})
-	}
+	})
 }
 
-func TestCountryCodeMsgPack(t *testing.T) {
+func TestCountryCodeEmoji(t *testing.T) {
 	for index, test := range []struct {
-		text          string
+		code          CountryCode
 		expectedValue string
-		expectedError string
 	}{
 		{
-			text:          "",
+			code:          "",
 			expectedValue: "",
 		},
 		{
-			text:          "fo",
-			expectedValue: "fo",
+			code:          "hu",
+			expectedValue: "🇭🇺",
 		},
 		{
-			text:          "Fo",
-			expectedValue: "fo",
+			code:          "DE",
+			expectedValue: "🇩🇪",
 		},
 		{
-			text:          "Foo",
-			expectedError: "invalid country code",
+			code:          "eu",
+			expectedValue: "🇪🇺",
 		},
 		{
-			text:          "12",
-			expectedError: "invalid country code",
+			code:          "t1",
+			expectedValue: "",
 		},
 	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			handle := &codec.MsgpackHandle{}
-
-			var textB []byte
-			err := codec.NewEncoderBytes(&textB, handle).Encode(test.text)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var code CountryCode
-			err = codec.NewDecoderBytes(textB, handle).Decode(&code)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-
-			var b []byte
-			err = codec.NewEncoderBytes(&b, handle).Encode(&code)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var str string
-			err = codec.NewDecoderBytes(b, handle).Decode(&str)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			if str != test.expectedValue {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, str)
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.code, test.expectedValue), func(t *testing.T) {
+			result := test.code.Emoji()
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
 			}
 		})
 	}
 }
 
-func TestCountryCodeJSON(t *testing.T) {
+func TestCountryCodeFromEmoji(t *testing.T) {
 	for index, test := range []struct {
-		text          string
-		expectedValue string
+		emoji         string
+		expectedValue CountryCode
 		expectedError string
 	}{
 		{
-			text:          "",
+			emoji:         "",
 			expectedValue: "",
 		},
 		{
-			text:          "fo",
-			expectedValue: "fo",
+			emoji:         "🇭🇺",
+			expectedValue: "hu",
 		},
 		{
-			text:          "Fo",
-			expectedValue: "fo",
+			emoji:         "🇪🇺",
+			expectedValue: "eu",
 		},
 		{
-			text:          "Foo",
-			expectedError: "invalid country code",
+			emoji:         "🇺🇸️",
+			expectedValue: "us",
 		},
 		{
-			text:          "12",
-			expectedError: "invalid country code",
-		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			textB, err := json.Marshal(test.text)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var code CountryCode
-			err = json.Unmarshal(textB, &code)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-
-			b, err := json.Marshal(code)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var str string
-			err = json.Unmarshal(b, &str)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			if !strings.EqualFold(str, test.expectedValue) {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, str)
-			}
-		})
-	}
-}
-
-func TestCountryCodeSql(t *testing.T) {
-	for index, test := range []struct {
-		text          string
-		expectedValue string
-		expectedError string
-	}{
-		{
-			text:          "",
-			expectedValue: "",
-		},
-		{
-			text:          "fo",
-			expectedValue: "fo",
+			emoji:         "🏴󠁧󠁢󠁥󠁮󠁧󠁿",
+			expectedError: "unknown code",
 		},
 		{
-			text:          "Fo",
-			expectedValue: "fo",
+			emoji:         "🏴‍☠️",
+			expectedError: "invalid country code",
 		},
 		{
-			text:          "Foo",
+			emoji:         "🇭",
 			expectedError: "invalid country code",
 		},
 		{
-			text:          "12",
+			emoji:         "hu",
 			expectedError: "invalid country code",
 		},
 	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			origCode, err := NewCountryCode(test.text)
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.emoji, test.expectedValue), func(t *testing.T) {
+			result, err := NewCountryCodeFromEmoji(test.emoji)
 			if err != nil {
 				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
 					return
@@ -245,30 +125,11 @@ func TestCountryCodeSql(t *testing.T) {
 			} else if test.expectedError != "" {
 				t.Errorf("expected error: %s, got none", test.expectedError)
 			}
-			driverValue, err := origCode.Value()
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			s, ok := driverValue.(string)
-			if !ok && test.text != "" {
-				t.Fatalf("value does not returned with a string, returned: %T", driverValue)
-			}
-
-			var scanValue CountryCode
-
-			if s == "" {
-				err = scanValue.Scan(nil)
-			} else {
-				err = scanValue.Scan(s)
-			}
-
-			if err != nil {
-				t.Fatal(err)
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
 			}
-
-			if scanValue.String() != test.expectedValue {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, scanValue.String())
+			if result.Emoji() != strings.TrimSuffix(test.emoji, "️") {
+				t.Fatalf("expected: %v, got: %v", test.emoji, result.Emoji())
 			}
 		})
 	}
@@ -0,0 +1,280 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownCountryGroup is returned by NewCountryPolicy for group names which are not registered.
+var ErrUnknownCountryGroup = errors.New("unknown country group")
+
+var countryGroupValidator = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+var (
+	countryGroupsMu sync.RWMutex
+	countryGroups   = map[string]CountryCodeSet{
+		"EU":   NewCountryCodeSet(euCountries...),
+		"EEA":  NewCountryCodeSet(append([]CountryCode{"is", "li", "no"}, euCountries...)...),
+		"EFTA": NewCountryCodeSet("ch", "is", "li", "no"),
+	}
+)
+
+var euCountries = []CountryCode{
+	"at", "be", "bg", "cy", "cz", "de", "dk", "ee", "es", "fi", "fr", "gr", "hr", "hu",
+	"ie", "it", "lt", "lu", "lv", "mt", "nl", "pl", "pt", "ro", "se", "si", "sk",
+}
+
+// CountryPolicy allows or denies country codes by a comma separated list of rules, eg. "EU,-hu,+ch,!t1":
+//   - "hu" or "+hu" allows, "-hu" denies hu, the last matching rule decides
+//   - "!hu" denies hu regardless of the order of the rules, eg. for sanctions
+//   - "*" matches all countries, eg. "*,-ru" allows all except ru
+//
+// Rules are country codes, "*", or country groups, eg. EU, EEA and EFTA, see RegisterCountryGroup. Countries matching
+// no rule are denied, so the empty policy denies all.
+//
+// It is marshalled to JSON and YAML as a string, and unmarshalled from a string or a list of rules.
+type CountryPolicy struct {
+	rules []countryPolicyRule
+}
+
+type countryPolicyRule struct {
+	// op is '+', '-', '!', or 0 for allow rules without a sign
+	op   byte
+	name string
+	// countries are the countries of the code or group, all countries if all is set
+	countries CountryCodeSet
+	all       bool
+}
+
+// RegisterCountryGroup registers or replaces the group name for use in country policies, eg.
+// RegisterCountryGroup("DACH", "de", "at", "ch"). Names are case-insensitive. Two-letter names are reserved for
+// country codes, assigned or not, except for the built-in EU, which takes precedence over the reserved country code.
+// Policies keep the countries of the group at the time they were parsed.
+func RegisterCountryGroup(name string, countries ...CountryCode) error {
+	if !countryGroupValidator.MatchString(name) {
+		return fmt.Errorf("invalid country group name: %s", name)
+	}
+
+	if (countryCodeSpec{}).ValidCode(name) && !strings.EqualFold(name, "eu") {
+		return fmt.Errorf("invalid country group name: %s: reserved for country codes", name)
+	}
+
+	for _, country := range countries {
+		if err := country.Validate(); err != nil {
+			return err
+		}
+	}
+
+	countryGroupsMu.Lock()
+	defer countryGroupsMu.Unlock()
+
+	countryGroups[strings.ToUpper(name)] = NewCountryCodeSet(countries...)
+
+	return nil
+}
+
+func unregisterCountryGroup(name string) {
+	countryGroupsMu.Lock()
+	defer countryGroupsMu.Unlock()
+
+	delete(countryGroups, strings.ToUpper(name))
+}
+
+// CountryGroup returns the countries of a registered group.
+func CountryGroup(name string) (CountryCodeSet, bool) {
+	countryGroupsMu.RLock()
+	defer countryGroupsMu.RUnlock()
+
+	countries, ok := countryGroups[strings.ToUpper(name)]
+
+	return countries.Clone(), ok
+}
+
+// NewCountryPolicy parses a policy, eg. "EU,-hu,+ch,!t1". Country codes are validated against ISO 3166, unknown
+// group names fail with ErrUnknownCountryGroup.
+func NewCountryPolicy(policy string) (CountryPolicy, error) {
+	if strings.TrimSpace(policy) == "" {
+		return CountryPolicy{}, nil
+	}
+
+	return newCountryPolicy(strings.Split(policy, ","))
+}
+
+func newCountryPolicy(rules []string) (CountryPolicy, error) {
+	p := CountryPolicy{rules: make([]countryPolicyRule, 0, len(rules))}
+	for _, rule := range rules {
+		r, err := parseCountryPolicyRule(strings.TrimSpace(rule))
+		if err != nil {
+			return CountryPolicy{}, err
+		}
+		p.rules = append(p.rules, r)
+	}
+
+	return p, nil
+}
+
+func parseCountryPolicyRule(rule string) (countryPolicyRule, error) {
+	var r countryPolicyRule
+	name := rule
+	if name != "" && strings.IndexByte("+-!", name[0]) >= 0 {
+		r.op = name[0]
+		name = strings.TrimSpace(name[1:])
+	}
+
+	if name == "" {
+		return r, fmt.Errorf("invalid country policy rule: %q", rule)
+	}
+
+	if name == "*" {
+		r.name = name
+		r.all = true
+		return r, nil
+	}
+
+	if countries, ok := CountryGroup(name); ok {
+		r.name = strings.ToUpper(name)
+		r.countries = countries
+		return r, nil
+	}
+
+	if !(countryCodeSpec{}).ValidCode(name) {
+		return r, fmt.Errorf("%w: %s", ErrUnknownCountryGroup, name)
+	}
+
+	country := CountryCode(strings.ToLower(name))
+	if err := country.Validate(); err != nil {
+		return r, err
+	}
+
+	r.name = country.String()
+	r.countries = NewCountryCodeSet(country)
+
+	return r, nil
+}
+
+// Allowed reports whether the policy allows the country, case-insensitively. The empty code is denied.
+func (p CountryPolicy) Allowed(c CountryCode) bool {
+	if c == "" {
+		return false
+	}
+
+	c = CountryCode(strings.ToLower(string(c)))
+
+	allowed := false
+	for _, r := range p.rules {
+		if !r.all && !r.countries.Contains(c) {
+			continue
+		}
+
+		switch r.op {
+		case '!':
+			return false
+		case '-':
+			allowed = false
+		default:
+			allowed = true
+		}
+	}
+
+	return allowed
+}
+
+// String returns the policy in the form parsed by NewCountryPolicy, with upper-case group names and lower-case codes.
+func (p CountryPolicy) String() string {
+	rules := make([]string, len(p.rules))
+	for i, r := range p.rules {
+		if r.op != 0 {
+			rules[i] = string(r.op)
+		}
+		rules[i] += r.name
+	}
+
+	return strings.Join(rules, ",")
+}
+
+func (p CountryPolicy) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *CountryPolicy) UnmarshalText(b []byte) error {
+	policy, err := NewCountryPolicy(string(b))
+	if err != nil {
+		return err
+	}
+
+	*p = policy
+
+	return nil
+}
+
+func (p CountryPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON accepts a string or an array of rules, null leaves p unchanged.
+func (p *CountryPolicy) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	var rules []string
+	if err := json.Unmarshal(b, &rules); err != nil {
+		var policy string
+		if err := json.Unmarshal(b, &policy); err != nil {
+			return fmt.Errorf("cannot unmarshal %s into CountryPolicy", b)
+		}
+		return p.UnmarshalText([]byte(policy))
+	}
+
+	policy, err := newCountryPolicy(rules)
+	if err != nil {
+		return err
+	}
+
+	*p = policy
+
+	return nil
+}
+
+func (p CountryPolicy) MarshalYAML() (interface{}, error) {
+	return p.String(), nil
+}
+
+// UnmarshalYAML accepts a string or a sequence of rules, null leaves p unchanged. Errors contain the line number.
+func (p *CountryPolicy) UnmarshalYAML(node *yaml.Node) error {
+	if node.ShortTag() == "!!null" {
+		return nil
+	}
+
+	var policy CountryPolicy
+	var err error
+	switch node.Kind {
+	case yaml.ScalarNode:
+		policy, err = NewCountryPolicy(node.Value)
+	case yaml.SequenceNode:
+		rules := make([]string, len(node.Content))
+		for i, rule := range node.Content {
+			if rule.Kind != yaml.ScalarNode {
+				return fmt.Errorf("yaml: line %d: cannot unmarshal %s into CountryPolicy rule", rule.Line, rule.ShortTag())
+			}
+			rules[i] = rule.Value
+		}
+		policy, err = newCountryPolicy(rules)
+	default:
+		return fmt.Errorf("yaml: line %d: cannot unmarshal %s into CountryPolicy", node.Line, node.ShortTag())
+	}
+	if err != nil {
+		return fmt.Errorf("yaml: line %d: %w", node.Line, err)
+	}
+
+	*p = policy
+
+	return nil
+}
@@ -0,0 +1,201 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestCountryPolicy(t *testing.T) {
+	for index, test := range []struct {
+		policy         string
+		expectedString string
+		allowed        []CountryCode
+		denied         []CountryCode
+		expectedError  string
+	}{
+		{
+			policy:         "EU,-hu,+ch,!t1",
+			expectedString: "EU,-hu,+ch,!t1",
+			allowed:        []CountryCode{"de", "DE", "at", "ch"},
+			denied:         []CountryCode{"hu", "HU", "t1", "T1", "us", ""},
+		},
+		{
+			policy:         " * , -RU, -by ",
+			expectedString: "*,-ru,-by",
+			allowed:        []CountryCode{"hu", "us", "t1"},
+			denied:         []CountryCode{"ru", "by"},
+		},
+		{
+			policy:         "!ru,eea,ru",
+			expectedString: "!ru,EEA,ru",
+			allowed:        []CountryCode{"no", "hu"},
+			denied:         []CountryCode{"ru", "ch"},
+		},
+		{
+			policy:         "-hu,EU",
+			expectedString: "-hu,EU",
+			allowed:        []CountryCode{"hu"},
+		},
+		{
+			policy:         "",
+			expectedString: "",
+			denied:         []CountryCode{"hu"},
+		},
+		{policy: "EU,NATO", expectedError: "unknown country group: NATO"},
+		{policy: "EU,xy", expectedError: "invalid country code: xy: unknown code"},
+		{policy: "EU,,hu", expectedError: `invalid country policy rule: ""`},
+		{policy: "EU,-", expectedError: `invalid country policy rule: "-"`},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.policy, test.expectedString), func(t *testing.T) {
+			result, err := NewCountryPolicy(test.policy)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			if result.String() != test.expectedString {
+				t.Errorf("expected: %s, got: %s", test.expectedString, result.String())
+			}
+			for _, country := range test.allowed {
+				if !result.Allowed(country) {
+					t.Errorf("expected %q to be allowed", country)
+				}
+			}
+			for _, country := range test.denied {
+				if result.Allowed(country) {
+					t.Errorf("expected %q to be denied", country)
+				}
+			}
+		})
+	}
+}
+
+func TestCountryPolicyUnknownGroup(t *testing.T) {
+	_, err := NewCountryPolicy("EU,NATO")
+	if !errors.Is(err, ErrUnknownCountryGroup) {
+		t.Fatalf("expected: %v, got: %v", ErrUnknownCountryGroup, err)
+	}
+}
+
+func TestRegisterCountryGroup(t *testing.T) {
+	if err := RegisterCountryGroup("dach", "de", "at", "ch"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { unregisterCountryGroup("dach") })
+
+	p, err := NewCountryPolicy("DACH,-ch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Allowed("at") || p.Allowed("ch") {
+		t.Errorf("unexpected policy: %v", p)
+	}
+
+	if err := RegisterCountryGroup("D-A-CH", "de"); err == nil || !strings.Contains(err.Error(), "invalid country group name: D-A-CH") {
+		t.Errorf("expected invalid name error, got: %v", err)
+	}
+	for _, name := range []string{"US", "hu", "T1", "XY"} {
+		if err := RegisterCountryGroup(name, "de"); err == nil || !strings.Contains(err.Error(), "reserved for country codes") {
+			t.Errorf("expected reserved name error for %s, got: %v", name, err)
+		}
+		if _, ok := CountryGroup(name); ok {
+			t.Errorf("expected %s not to be registered", name)
+		}
+	}
+	if err := RegisterCountryGroup("BAD", "de", "xy"); err == nil || !strings.Contains(err.Error(), "invalid country code: xy") {
+		t.Errorf("expected invalid country code error, got: %v", err)
+	}
+	if _, ok := CountryGroup("BAD"); ok {
+		t.Errorf("expected BAD not to be registered")
+	}
+}
+
+func TestCountryPolicyJSON(t *testing.T) {
+	for index, test := range []struct {
+		json          string
+		expectedJSON  string
+		expectedError string
+	}{
+		{json: `{"countries":"EU,-hu"}`, expectedJSON: `{"countries":"EU,-hu"}`},
+		{json: `{"countries":["*","-RU","!t1"]}`, expectedJSON: `{"countries":"*,-ru,!t1"}`},
+		{json: `{"countries":null}`, expectedJSON: `{"countries":"ch"}`},
+		{json: `{"countries":"EU,NATO"}`, expectedError: "unknown country group: NATO"},
+		{json: `{"countries":1}`, expectedError: "cannot unmarshal 1 into CountryPolicy"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.json, test.expectedJSON), func(t *testing.T) {
+			var result struct {
+				Countries CountryPolicy `json:"countries"`
+			}
+			result.Countries, _ = NewCountryPolicy("ch")
+
+			err := json.Unmarshal([]byte(test.json), &result)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			b, err := json.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expectedJSON {
+				t.Fatalf("expected: %s, got: %s", test.expectedJSON, b)
+			}
+		})
+	}
+}
+
+func TestCountryPolicyYAML(t *testing.T) {
+	for index, test := range []struct {
+		yaml          string
+		expectedYAML  string
+		expectedError string
+	}{
+		{yaml: "countries: EU,-hu,+ch\n", expectedYAML: "countries: EU,-hu,+ch\n"},
+		{yaml: "countries:\n  - EEA\n  - -NO\n  - '!t1'\n", expectedYAML: "countries: EEA,-no,!t1\n"},
+		{yaml: "countries:\n", expectedYAML: "countries: ch\n"},
+		{yaml: "name: x\ncountries: EU,NATO\n", expectedError: "yaml: line 2: unknown country group: NATO"},
+		{yaml: "countries:\n  - [hu]\n", expectedError: "yaml: line 2: cannot unmarshal !!seq into CountryPolicy rule"},
+		{yaml: "countries: {hu: true}\n", expectedError: "yaml: line 1: cannot unmarshal !!map into CountryPolicy"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %q -> %q", index+1, test.yaml, test.expectedYAML), func(t *testing.T) {
+			var result struct {
+				Name      string        `yaml:"name,omitempty"`
+				Countries CountryPolicy `yaml:"countries"`
+			}
+			result.Countries, _ = NewCountryPolicy("ch")
+
+			err := yaml.Unmarshal([]byte(test.yaml), &result)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			result.Name = ""
+			b, err := yaml.Marshal(result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expectedYAML {
+				t.Fatalf("expected: %q, got: %q", test.expectedYAML, b)
+			}
+		})
+	}
+}
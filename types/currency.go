@@ -1,106 +1,128 @@
 package types
 
 import (
-	"bytes"
 	"database/sql/driver"
-	"fmt"
+	"encoding/xml"
 	"regexp"
-	"strconv"
 	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 var currencyValidator = regexp.MustCompile(`^[A-Za-z]{3}$`)
 
-// ISO 4217 alphabetical currency code
+// ISO 4217 alphabetical currency code, or a code registered with RegisterCurrency
 type Currency string
 
-func NewCurrency(currency string) (Currency, error) {
-	if currency == "" {
-		return "", nil
-	}
+type currencySpec struct{}
 
-	if !currencyValidator.MatchString(currency) {
-		return "", fmt.Errorf("invalid currency: %s", currency)
-	}
+func (currencySpec) CodeName() string {
+	return "currency"
+}
 
-	return Currency(strings.ToLower(currency)), nil
+func (currencySpec) ValidCode(code string) bool {
+	return currencyValidator.MatchString(code) || Currency(strings.ToLower(code)).IsRegistered()
+}
+
+func NewCurrency(currency string) (Currency, error) {
+	return parseCode[Currency](currencySpec{}, currency)
 }
 
 func (c Currency) String() string {
 	return string(c)
 }
 
-func (c Currency) MarshalText() ([]byte, error) {
-	return []byte(c.String()), nil
+// Upper returns the upper-case code, eg. "EUR".
+func (c Currency) Upper() string {
+	return strings.ToUpper(string(c))
 }
 
-func (c *Currency) UnmarshalText(b []byte) error {
-	currency, err := NewCurrency(string(b))
-	if err != nil {
-		return err
+// ISO returns the upper-case ISO 4217 code, or an empty string for currencies not in ISO 4217 (eg. registered ones).
+func (c Currency) ISO() string {
+	if _, ok := lookupISO4217(c); !ok {
+		return ""
 	}
 
-	*c = currency
+	return c.Upper()
+}
 
-	return nil
+func (c Currency) MarshalText() ([]byte, error) {
+	return marshalCodeText(withOutputCase(c))
 }
 
-func (c Currency) MarshalJSON() ([]byte, error) {
-	if c.String() == "" {
-		return []byte("null"), nil
-	}
+func (c *Currency) UnmarshalText(b []byte) error {
+	return unmarshalCodeText(c, b, NewCurrency)
+}
 
-	return []byte(strconv.Quote(c.String())), nil
+func (c Currency) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(withOutputCase(c))
 }
 
 func (c *Currency) UnmarshalJSON(b []byte) error {
-	if bytes.Equal(b, []byte("null")) {
-		return nil
-	}
+	return unmarshalCodeJSON(c, b, NewCurrency)
+}
 
-	str, err := strconv.Unquote(string(b))
-	if err != nil {
-		return err
-	}
+func (c Currency) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(withOutputCase(c))
+}
 
-	currency, err := NewCurrency(str)
-	if err != nil {
-		return err
-	}
+func (c *Currency) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(c, node, NewCurrency)
+}
 
-	*c = currency
+func (c Currency) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(withXMLOutputCase(c), e, start)
+}
 
-	return nil
+func (c *Currency) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(c, d, start, NewCurrency)
+}
+
+func (c Currency) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(withXMLOutputCase(c), name)
+}
+
+func (c *Currency) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(c, attr, NewCurrency)
 }
 
+// MarshalBinary returns the text form, or the compact form if enabled by SetBinaryCompact.
 func (c Currency) MarshalBinary() ([]byte, error) {
-	return c.MarshalText()
+	return marshalCodeBinary(c, currencyNumeric)
 }
 
+// UnmarshalBinary accepts both the text and the compact form.
 func (c *Currency) UnmarshalBinary(b []byte) error {
-	return c.UnmarshalText(b)
+	return unmarshalCodeBinary(c, b, "currency", NewCurrency, currencyFromNumeric)
 }
 
 func (c Currency) Value() (driver.Value, error) {
-	if c.String() == "" {
-		return nil, nil
-	}
-
-	return c.String(), nil
+	return codeValue(withOutputCase(c))
 }
 
 func (c *Currency) Scan(src interface{}) error {
-	if src == nil {
-		*c = ""
+	return scanCode(c, src, NewCurrency, "Currency")
+}
+
+// Validate returns an *InvalidCodeError if the currency is malformed, neither in ISO 4217 nor registered, or withdrawn.
+// The empty code is valid.
+func (c Currency) Validate() error {
+	if c == "" || c.IsRegistered() {
 		return nil
 	}
 
-	if src, ok := src.(string); ok {
-		var err error
-		*c, err = NewCurrency(src)
+	if !(currencySpec{}).ValidCode(c.String()) {
+		return &InvalidCodeError{Kind: "currency", Input: c.String(), Reason: ErrInvalidFormat}
+	}
 
-		return err
+	info, ok := lookupISO4217(Currency(strings.ToLower(c.String())))
+	switch {
+	case !ok:
+		return &InvalidCodeError{Kind: "currency", Input: c.String(), Reason: ErrUnknownCode}
+	case !info.withdrawn.IsZero() && !time.Now().Before(info.withdrawn):
+		return &InvalidCodeError{Kind: "currency", Input: c.String(), Reason: ErrWithdrawnCode}
 	}
 
-	return fmt.Errorf("cannot convert %T to Currency", src)
+	return nil
 }
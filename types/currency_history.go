@@ -0,0 +1,73 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// IsActiveAt reports whether the currency was in use at t according to ISO 4217. Currencies are considered
+// active before their introduction date if it is not known, registered currencies are always active,
+// unknown codes never.
+func (c Currency) IsActiveAt(t time.Time) bool {
+	info, ok := lookupISO4217(c)
+	if !ok {
+		return c.IsRegistered()
+	}
+
+	if !info.introduced.IsZero() && t.Before(info.introduced) {
+		return false
+	}
+
+	return info.withdrawn.IsZero() || t.Before(info.withdrawn)
+}
+
+// WithdrawnAt returns the date the currency was withdrawn from ISO 4217, or the zero time if it is current.
+func (c Currency) WithdrawnAt() time.Time {
+	info, _ := lookupISO4217(c)
+
+	return info.withdrawn
+}
+
+// Successor returns the currency that replaced a withdrawn currency, and the fixed number of units exchanged
+// for one unit of the successor, eg. EUR and 1.95583 for DEM. The rate is nil if there was no fixed rate,
+// the successor is empty if the currency is current or had no successor.
+func (c Currency) Successor() (Currency, *big.Rat) {
+	info, ok := lookupISO4217(c)
+	if !ok || info.successor == "" {
+		return "", nil
+	}
+
+	if info.rate == "" {
+		return info.successor, nil
+	}
+
+	rate, ok := new(big.Rat).SetString(info.rate)
+	if !ok {
+		return info.successor, nil
+	}
+
+	return info.successor, rate
+}
+
+// LatestSuccessor follows the successors of the currency through redenominations (eg. ZWD, ZWN, ZWR, ZWL, ZWG),
+// and returns the last one with the combined rate. The rate is nil if any of the steps had no fixed rate.
+func (c Currency) LatestSuccessor() (Currency, *big.Rat) {
+	latest, rate := c.Successor()
+	if latest == "" {
+		return "", nil
+	}
+
+	for {
+		next, nextRate := latest.Successor()
+		if next == "" {
+			return latest, rate
+		}
+
+		if rate != nil && nextRate != nil {
+			rate.Mul(rate, nextRate)
+		} else {
+			rate = nil
+		}
+		latest = next
+	}
+}
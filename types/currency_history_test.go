@@ -0,0 +1,198 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCurrencyIsActiveAt(t *testing.T) {
+	for index, test := range []struct {
+		currency      Currency
+		time          string
+		expectedValue bool
+	}{
+		{
+			currency:      "huf",
+			time:          "1980-01-01",
+			expectedValue: true,
+		},
+		{
+			currency:      "dem",
+			time:          "2001-12-31",
+			expectedValue: true,
+		},
+		{
+			currency:      "dem",
+			time:          "2002-03-01",
+			expectedValue: false,
+		},
+		{
+			currency:      "eur",
+			time:          "1998-12-31",
+			expectedValue: false,
+		},
+		{
+			currency:      "eur",
+			time:          "1999-01-01",
+			expectedValue: true,
+		},
+		{
+			currency:      "zwl",
+			time:          "2024-08-31",
+			expectedValue: true,
+		},
+		{
+			currency:      "zwl",
+			time:          "2024-09-01",
+			expectedValue: false,
+		},
+		{
+			currency:      "hrk",
+			time:          "2022-12-31",
+			expectedValue: true,
+		},
+		{
+			currency:      "hrk",
+			time:          "2023-01-01",
+			expectedValue: false,
+		},
+		{
+			currency:      "bgn",
+			time:          "1999-07-04",
+			expectedValue: false,
+		},
+		{
+			currency:      "bgn",
+			time:          "2025-12-31",
+			expectedValue: true,
+		},
+		{
+			currency:      "bgn",
+			time:          "2026-01-01",
+			expectedValue: false,
+		},
+		{
+			currency:      "foo",
+			time:          "2020-01-01",
+			expectedValue: false,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v@%v -> %v", index+1, test.currency, test.time, test.expectedValue), func(t *testing.T) {
+			at, err := time.Parse("2006-01-02", test.time)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result := test.currency.IsActiveAt(at); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCurrencyWithdrawnAt(t *testing.T) {
+	if withdrawn := Currency("frf").WithdrawnAt(); withdrawn.Format("2006-01-02") != "2002-03-01" {
+		t.Fatalf("expected: 2002-03-01, got: %v", withdrawn)
+	}
+
+	if withdrawn := Currency("eur").WithdrawnAt(); !withdrawn.IsZero() {
+		t.Fatalf("expected zero time, got: %v", withdrawn)
+	}
+
+	if minorUnits, ok := Currency("itl").MinorUnits(); !ok || minorUnits != 0 {
+		t.Fatalf("expected: 0, got: %v", minorUnits)
+	}
+}
+
+func TestCurrencySuccessor(t *testing.T) {
+	for index, test := range []struct {
+		currency           Currency
+		expectedSuccessor  Currency
+		expectedRate       string
+		expectedLatest     Currency
+		expectedLatestRate string
+	}{
+		{
+			currency: "eur",
+		},
+		{
+			currency: "foo",
+		},
+		{
+			currency:           "dem",
+			expectedSuccessor:  "eur",
+			expectedRate:       "1.95583",
+			expectedLatest:     "eur",
+			expectedLatestRate: "1.95583",
+		},
+		{
+			currency:           "itl",
+			expectedSuccessor:  "eur",
+			expectedRate:       "1936.27",
+			expectedLatest:     "eur",
+			expectedLatestRate: "1936.27",
+		},
+		{
+			currency:           "veb",
+			expectedSuccessor:  "vef",
+			expectedRate:       "1000",
+			expectedLatest:     "ves",
+			expectedLatestRate: "100000000",
+		},
+		{
+			currency:          "zwd",
+			expectedSuccessor: "zwn",
+			expectedRate:      "1000",
+			expectedLatest:    "zwg",
+		},
+		{
+			currency:          "zwl",
+			expectedSuccessor: "zwg",
+			expectedLatest:    "zwg",
+		},
+		{
+			currency:           "bgl",
+			expectedSuccessor:  "bgn",
+			expectedRate:       "1000",
+			expectedLatest:     "eur",
+			expectedLatestRate: "1955.83",
+		},
+		{
+			currency:          "ddm",
+			expectedSuccessor: "dem",
+			expectedLatest:    "eur",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.currency, test.expectedSuccessor), func(t *testing.T) {
+			successor, rate := test.currency.Successor()
+			if successor != test.expectedSuccessor {
+				t.Fatalf("expected: %v, got: %v", test.expectedSuccessor, successor)
+			}
+			expectRate(t, rate, test.expectedRate)
+
+			latest, latestRate := test.currency.LatestSuccessor()
+			if latest != test.expectedLatest {
+				t.Fatalf("expected: %v, got: %v", test.expectedLatest, latest)
+			}
+			expectRate(t, latestRate, test.expectedLatestRate)
+		})
+	}
+}
+
+func expectRate(t *testing.T, rate *big.Rat, expected string) {
+	t.Helper()
+
+	if expected == "" {
+		if rate != nil {
+			t.Fatalf("expected no rate, got: %v", rate.RatString())
+		}
+		return
+	}
+
+	expectedRate, _ := new(big.Rat).SetString(expected)
+	if rate == nil || rate.Cmp(expectedRate) != 0 {
+		t.Fatalf("expected rate: %v, got: %v", expected, rate)
+	}
+}
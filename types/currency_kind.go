@@ -0,0 +1,83 @@
+package types
+
+import (
+	"fmt"
+)
+
+// CurrencyKind classifies currencies as in the ISO 4217 list.
+type CurrencyKind int
+
+const (
+	// CurrencyKindUnknown is used for codes neither in ISO 4217 nor registered.
+	CurrencyKindUnknown CurrencyKind = iota
+	// CurrencyKindNational is the currency of a country, eg. HUF.
+	CurrencyKindNational
+	// CurrencyKindPreciousMetal is a troy ounce of a precious metal, eg. XAU.
+	CurrencyKindPreciousMetal
+	// CurrencyKindFund is a fund or unit of account tied to a national currency, eg. CLF, USN.
+	CurrencyKindFund
+	// CurrencyKindSupranational is a currency of a monetary union (eg. EUR, XOF), or a supranational unit of account (eg. XDR).
+	CurrencyKindSupranational
+	// CurrencyKindTesting is reserved for testing, XTS.
+	CurrencyKindTesting
+	// CurrencyKindNoCurrency denotes transactions where no currency is involved, XXX.
+	CurrencyKindNoCurrency
+	// CurrencyKindRegistered is a currency registered with RegisterCurrency.
+	CurrencyKindRegistered
+)
+
+var currencyKindNames = map[CurrencyKind]string{
+	CurrencyKindUnknown:       "unknown",
+	CurrencyKindNational:      "national",
+	CurrencyKindPreciousMetal: "precious-metal",
+	CurrencyKindFund:          "fund",
+	CurrencyKindSupranational: "supranational",
+	CurrencyKindTesting:       "testing",
+	CurrencyKindNoCurrency:    "no-currency",
+	CurrencyKindRegistered:    "registered",
+}
+
+func (k CurrencyKind) String() string {
+	if name, ok := currencyKindNames[k]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("CurrencyKind(%d)", int(k))
+}
+
+func parseCurrencyKind(name string) (CurrencyKind, error) {
+	for kind, kindName := range currencyKindNames {
+		if kindName == name {
+			return kind, nil
+		}
+	}
+
+	return CurrencyKindUnknown, fmt.Errorf("invalid currency kind: %s", name)
+}
+
+func (c Currency) Kind() CurrencyKind {
+	if info, ok := lookupISO4217(c); ok {
+		return info.kind
+	}
+
+	if c.IsRegistered() {
+		return CurrencyKindRegistered
+	}
+
+	return CurrencyKindUnknown
+}
+
+// IsTender reports whether the currency is a national or supranational currency used for payments, ie. not
+// a fund, a precious metal, a unit of account (eg. XDR), a testing, a withdrawn or a registered currency.
+func (c Currency) IsTender() bool {
+	info, ok := lookupISO4217(c)
+	if !ok {
+		return false
+	}
+
+	if !info.withdrawn.IsZero() {
+		return false
+	}
+
+	return (info.kind == CurrencyKindNational || info.kind == CurrencyKindSupranational) && info.minorUnits >= 0
+}
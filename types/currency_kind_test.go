@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCurrencyKind(t *testing.T) {
+	if _, err := RegisterCurrency(CurrencyDefinition{Code: "KND", Precision: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	for index, test := range []struct {
+		currency       Currency
+		expectedKind   CurrencyKind
+		expectedTender bool
+	}{
+		{
+			currency:       "",
+			expectedKind:   CurrencyKindUnknown,
+			expectedTender: false,
+		},
+		{
+			currency:       "huf",
+			expectedKind:   CurrencyKindNational,
+			expectedTender: true,
+		},
+		{
+			currency:       "eur",
+			expectedKind:   CurrencyKindSupranational,
+			expectedTender: true,
+		},
+		{
+			currency:       "EUR",
+			expectedKind:   CurrencyKindSupranational,
+			expectedTender: true,
+		},
+		{
+			currency:       "xof",
+			expectedKind:   CurrencyKindSupranational,
+			expectedTender: true,
+		},
+		{
+			currency:       "xdr",
+			expectedKind:   CurrencyKindSupranational,
+			expectedTender: false,
+		},
+		{
+			currency:       "dem",
+			expectedKind:   CurrencyKindNational,
+			expectedTender: false,
+		},
+		{
+			currency:       "xau",
+			expectedKind:   CurrencyKindPreciousMetal,
+			expectedTender: false,
+		},
+		{
+			currency:       "xag",
+			expectedKind:   CurrencyKindPreciousMetal,
+			expectedTender: false,
+		},
+		{
+			currency:       "bov",
+			expectedKind:   CurrencyKindFund,
+			expectedTender: false,
+		},
+		{
+			currency:       "clf",
+			expectedKind:   CurrencyKindFund,
+			expectedTender: false,
+		},
+		{
+			currency:       "usn",
+			expectedKind:   CurrencyKindFund,
+			expectedTender: false,
+		},
+		{
+			currency:       "xts",
+			expectedKind:   CurrencyKindTesting,
+			expectedTender: false,
+		},
+		{
+			currency:       "xxx",
+			expectedKind:   CurrencyKindNoCurrency,
+			expectedTender: false,
+		},
+		{
+			currency:       "knd",
+			expectedKind:   CurrencyKindRegistered,
+			expectedTender: false,
+		},
+		{
+			currency:       "KND",
+			expectedKind:   CurrencyKindRegistered,
+			expectedTender: false,
+		},
+		{
+			currency:       "foo",
+			expectedKind:   CurrencyKindUnknown,
+			expectedTender: false,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.currency, test.expectedKind), func(t *testing.T) {
+			if kind := test.currency.Kind(); kind != test.expectedKind {
+				t.Fatalf("expected: %v, got: %v", test.expectedKind, kind)
+			}
+			if tender := test.currency.IsTender(); tender != test.expectedTender {
+				t.Fatalf("expected tender: %v, got: %v", test.expectedTender, tender)
+			}
+		})
+	}
+}
+
+func TestCurrencyKindString(t *testing.T) {
+	for index, test := range []struct {
+		kind          CurrencyKind
+		expectedValue string
+	}{
+		{
+			kind:          CurrencyKindPreciousMetal,
+			expectedValue: "precious-metal",
+		},
+		{
+			kind:          CurrencyKindNoCurrency,
+			expectedValue: "no-currency",
+		},
+		{
+			kind:          CurrencyKind(100),
+			expectedValue: "CurrencyKind(100)",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test.expectedValue), func(t *testing.T) {
+			if result := test.kind.String(); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
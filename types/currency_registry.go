@@ -0,0 +1,220 @@
+package types
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxCurrencyCodeLength is the maximum length of a registered currency code.
+const MaxCurrencyCodeLength = 10
+
+// MaxCurrencyPrecision is the maximum number of decimals of a registered currency.
+const MaxCurrencyPrecision = 18
+
+// iso4217.csv is the ISO 4217 list of current currencies, minor units are empty where not applicable (eg. XAU),
+// kind is the name of a CurrencyKind
+//
+//go:embed data/iso4217.csv
+var iso4217CSV string
+
+// iso4217_historic.csv is the ISO 4217 list of historic currencies, with the fixed rate to the successor currency
+// where there was one
+//
+//go:embed data/iso4217_historic.csv
+var iso4217HistoricCSV string
+
+var registeredCurrencyValidator = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+var (
+	iso4217Once       sync.Once
+	iso4217           map[Currency]currencyInfo
+	currencyByNumeric map[int]Currency
+
+	registryMu sync.RWMutex
+	registry   = make(map[Currency]CurrencyDefinition)
+)
+
+type currencyInfo struct {
+	numeric    int
+	minorUnits int
+	kind       CurrencyKind
+	introduced time.Time
+	withdrawn  time.Time
+	successor  Currency
+	rate       string
+}
+
+// CurrencyDefinition describes a currency not in ISO 4217, eg. a cryptocurrency.
+type CurrencyDefinition struct {
+	// Code is case-insensitive, 2 to MaxCurrencyCodeLength letters or digits.
+	Code string
+	// Precision is the number of decimals of the smallest unit, at most MaxCurrencyPrecision.
+	Precision int
+	// Symbol is used by Currency.Symbol and Currency.NarrowSymbol, the upper-case code is used if empty.
+	Symbol string
+}
+
+// CryptoCurrencies are the common cryptocurrencies registered by RegisterCryptoCurrencies.
+var CryptoCurrencies = []CurrencyDefinition{
+	{Code: "BTC", Precision: 8, Symbol: "₿"},
+	{Code: "ETH", Precision: 18, Symbol: "Ξ"},
+	{Code: "LTC", Precision: 8, Symbol: "Ł"},
+	{Code: "XRP", Precision: 6},
+	{Code: "SOL", Precision: 9},
+	{Code: "USDC", Precision: 6},
+	{Code: "USDT", Precision: 6},
+	{Code: "DAI", Precision: 18},
+}
+
+// RegisterCurrency makes a non ISO 4217 currency accepted by NewCurrency, and its precision known to MinorUnits.
+// Registering a code again replaces its definition, ISO 4217 codes cannot be registered.
+func RegisterCurrency(def CurrencyDefinition) (Currency, error) {
+	if len(def.Code) < 2 || len(def.Code) > MaxCurrencyCodeLength || !registeredCurrencyValidator.MatchString(def.Code) {
+		return "", &InvalidCodeError{Kind: "currency", Input: def.Code, Reason: ErrInvalidFormat}
+	}
+
+	if def.Precision < 0 || def.Precision > MaxCurrencyPrecision {
+		return "", fmt.Errorf("invalid currency precision: %s: %d", def.Code, def.Precision)
+	}
+
+	currency := Currency(strings.ToLower(def.Code))
+	if _, ok := lookupISO4217(currency); ok {
+		return "", fmt.Errorf("currency is defined by ISO 4217: %s", def.Code)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	registry[currency] = def
+
+	return currency, nil
+}
+
+// RegisterCryptoCurrencies registers the CryptoCurrencies.
+func RegisterCryptoCurrencies() {
+	for _, def := range CryptoCurrencies {
+		if _, err := RegisterCurrency(def); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// MinorUnits returns the number of decimals of the currency's minor unit, eg. 2 for EUR (cent), 0 for JPY.
+// Returns false for unknown currencies, and where minor units are not applicable (eg. XAU).
+func (c Currency) MinorUnits() (int, bool) {
+	if info, ok := lookupISO4217(c); ok {
+		if info.minorUnits < 0 {
+			return 0, false
+		}
+		return info.minorUnits, true
+	}
+
+	if def, ok := lookupRegistry(c); ok {
+		return def.Precision, true
+	}
+
+	return 0, false
+}
+
+// IsRegistered reports whether the currency is a registered non ISO 4217 currency.
+func (c Currency) IsRegistered() bool {
+	_, ok := lookupRegistry(c)
+	return ok
+}
+
+func lookupRegistry(c Currency) (CurrencyDefinition, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	def, ok := registry[Currency(strings.ToLower(c.String()))]
+
+	return def, ok
+}
+
+func lookupISO4217(c Currency) (currencyInfo, bool) {
+	iso4217Once.Do(loadISO4217)
+
+	info, ok := iso4217[Currency(strings.ToLower(c.String()))]
+
+	return info, ok
+}
+
+func loadISO4217() {
+	iso4217 = make(map[Currency]currencyInfo)
+
+	for _, table := range []string{iso4217CSV, iso4217HistoricCSV} {
+		if err := parseISO4217(table); err != nil {
+			panic(fmt.Sprintf("types: invalid embedded iso4217 table: %v", err))
+		}
+	}
+
+	// numeric codes are reused, prefer the current currency, then the latest withdrawn one
+	currencyByNumeric = make(map[int]Currency)
+	for currency, info := range iso4217 {
+		if info.numeric == 0 {
+			continue
+		}
+
+		if prev, ok := currencyByNumeric[info.numeric]; ok {
+			prevInfo := iso4217[prev]
+			if prevInfo.withdrawn.IsZero() || (!info.withdrawn.IsZero() && info.withdrawn.Before(prevInfo.withdrawn)) {
+				continue
+			}
+		}
+
+		currencyByNumeric[info.numeric] = currency
+	}
+}
+
+func parseISO4217(table string) error {
+	records, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records[1:] {
+		info := currencyInfo{
+			minorUnits: -1,
+			successor:  Currency(record[6]),
+			rate:       record[7],
+		}
+
+		if record[1] != "" {
+			if info.numeric, err = strconv.Atoi(record[1]); err != nil {
+				return err
+			}
+		}
+
+		if record[2] != "" {
+			if info.minorUnits, err = strconv.Atoi(record[2]); err != nil {
+				return err
+			}
+		}
+
+		if info.kind, err = parseCurrencyKind(record[3]); err != nil {
+			return err
+		}
+
+		if record[4] != "" {
+			if info.introduced, err = time.Parse("2006-01-02", record[4]); err != nil {
+				return err
+			}
+		}
+
+		if record[5] != "" {
+			if info.withdrawn, err = time.Parse("2006-01-02", record[5]); err != nil {
+				return err
+			}
+		}
+
+		iso4217[Currency(record[0])] = info
+	}
+
+	return nil
+}
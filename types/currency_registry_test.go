@@ -0,0 +1,159 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRegisterCurrency(t *testing.T) {
+	for index, test := range []struct {
+		def           CurrencyDefinition
+		expectedValue Currency
+		expectedError string
+	}{
+		{
+			def:           CurrencyDefinition{Code: "TST1", Precision: 4, Symbol: "T"},
+			expectedValue: "tst1",
+		},
+		{
+			def:           CurrencyDefinition{Code: "tst2", Precision: 18},
+			expectedValue: "tst2",
+		},
+		{
+			def:           CurrencyDefinition{Code: "T", Precision: 2},
+			expectedError: "invalid currency",
+		},
+		{
+			def:           CurrencyDefinition{Code: "TESTTESTTEST", Precision: 2},
+			expectedError: "invalid currency",
+		},
+		{
+			def:           CurrencyDefinition{Code: "TS-T", Precision: 2},
+			expectedError: "invalid currency",
+		},
+		{
+			def:           CurrencyDefinition{Code: "TST3", Precision: 19},
+			expectedError: "invalid currency precision",
+		},
+		{
+			def:           CurrencyDefinition{Code: "EUR", Precision: 3},
+			expectedError: "currency is defined by ISO 4217",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.def.Code, test.expectedValue), func(t *testing.T) {
+			result, err := RegisterCurrency(test.def)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+
+			currency, err := NewCurrency(test.def.Code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if currency != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, currency)
+			}
+		})
+	}
+}
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	RegisterCryptoCurrencies()
+
+	for index, test := range []struct {
+		currency      Currency
+		expectedValue int
+		expectedOk    bool
+	}{
+		{
+			currency:   "",
+			expectedOk: false,
+		},
+		{
+			currency:      "eur",
+			expectedValue: 2,
+			expectedOk:    true,
+		},
+		{
+			currency:      "EUR",
+			expectedValue: 2,
+			expectedOk:    true,
+		},
+		{
+			currency:      "jpy",
+			expectedValue: 0,
+			expectedOk:    true,
+		},
+		{
+			currency:      "bhd",
+			expectedValue: 3,
+			expectedOk:    true,
+		},
+		{
+			currency:      "clf",
+			expectedValue: 4,
+			expectedOk:    true,
+		},
+		{
+			currency:   "xau",
+			expectedOk: false,
+		},
+		{
+			currency:   "foo",
+			expectedOk: false,
+		},
+		{
+			currency:      "btc",
+			expectedValue: 8,
+			expectedOk:    true,
+		},
+		{
+			currency:      "eth",
+			expectedValue: 18,
+			expectedOk:    true,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.currency, test.expectedValue), func(t *testing.T) {
+			result, ok := test.currency.MinorUnits()
+			if ok != test.expectedOk {
+				t.Fatalf("expected ok: %v, got: %v", test.expectedOk, ok)
+			}
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCurrencyRegistered(t *testing.T) {
+	if _, err := NewCurrency("usdx"); err == nil || !strings.Contains(err.Error(), "invalid currency") {
+		t.Fatalf("expected error: invalid currency, got: %v", err)
+	}
+
+	if _, err := RegisterCurrency(CurrencyDefinition{Code: "USDX", Precision: 6, Symbol: "X$"}); err != nil {
+		t.Fatal(err)
+	}
+
+	currency, err := NewCurrency("UsdX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currency != "usdx" {
+		t.Fatalf("expected: usdx, got: %v", currency)
+	}
+	if symbol := currency.Symbol("en", "us"); symbol != "X$" {
+		t.Fatalf("expected: X$, got: %v", symbol)
+	}
+	if symbol := currency.NarrowSymbol(); symbol != "X$" {
+		t.Fatalf("expected: X$, got: %v", symbol)
+	}
+}
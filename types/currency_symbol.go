@@ -0,0 +1,90 @@
+package types
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// currency_symbols.csv is a subset of the CLDR currency symbols, the empty locale is the CLDR root locale
+//
+//go:embed data/currency_symbols.csv
+var currencySymbolsCSV string
+
+var (
+	currencySymbolsOnce sync.Once
+	currencySymbols     map[Currency]map[string]string
+	currencyNarrow      map[Currency]string
+)
+
+// Symbol returns the symbol of the currency in the locale given by lang and country, eg. "US$" for USD in en-CA.
+// Falls back to the symbol used for the language, then to the CLDR root symbol or the symbol of a registered
+// currency, and finally to the upper-case code.
+// Country can be empty.
+func (c Currency) Symbol(lang Language, country CountryCode) string {
+	if c == "" {
+		return ""
+	}
+
+	currencySymbolsOnce.Do(loadCurrencySymbols)
+
+	symbols := currencySymbols[Currency(strings.ToLower(c.String()))]
+	if lang != "" && country != "" {
+		if symbol, ok := symbols[strings.ToLower(lang.String()+"-"+country.String())]; ok {
+			return symbol
+		}
+	}
+	if lang != "" {
+		if symbol, ok := symbols[strings.ToLower(lang.String())]; ok {
+			return symbol
+		}
+	}
+	if symbol, ok := symbols[""]; ok {
+		return symbol
+	}
+	if def, ok := lookupRegistry(c); ok && def.Symbol != "" {
+		return def.Symbol
+	}
+
+	return c.Upper()
+}
+
+// NarrowSymbol returns the locale independent narrow symbol, eg. "$" for USD. Currencies without a narrow
+// symbol return their CLDR root symbol.
+func (c Currency) NarrowSymbol() string {
+	if c == "" {
+		return ""
+	}
+
+	currencySymbolsOnce.Do(loadCurrencySymbols)
+
+	if symbol, ok := currencyNarrow[Currency(strings.ToLower(c.String()))]; ok {
+		return symbol
+	}
+
+	return c.Symbol("", "")
+}
+
+func loadCurrencySymbols() {
+	currencySymbols = make(map[Currency]map[string]string)
+	currencyNarrow = make(map[Currency]string)
+
+	records, err := csv.NewReader(strings.NewReader(currencySymbolsCSV)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("types: invalid embedded currency symbols: %v", err))
+	}
+
+	for _, record := range records[1:] {
+		currency, locale := Currency(record[0]), record[1]
+		if currencySymbols[currency] == nil {
+			currencySymbols[currency] = make(map[string]string)
+		}
+		currencySymbols[currency][locale] = record[2]
+
+		if record[3] != "" {
+			currencyNarrow[currency] = record[3]
+		}
+	}
+}
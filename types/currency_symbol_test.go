@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCurrencySymbol(t *testing.T) {
+	for index, test := range []struct {
+		currency      Currency
+		lang          Language
+		country       CountryCode
+		expectedValue string
+	}{
+		{
+			currency:      "",
+			lang:          "en",
+			expectedValue: "",
+		},
+		{
+			currency:      "usd",
+			lang:          "en",
+			country:       "us",
+			expectedValue: "$",
+		},
+		{
+			currency:      "usd",
+			lang:          "en",
+			country:       "ca",
+			expectedValue: "US$",
+		},
+		{
+			currency:      "USD",
+			lang:          "EN",
+			country:       "CA",
+			expectedValue: "US$",
+		},
+		{
+			currency:      "usd",
+			lang:          "fr",
+			country:       "ca",
+			expectedValue: "$\u00a0US",
+		},
+		{
+			currency:      "usd",
+			lang:          "fr",
+			country:       "fr",
+			expectedValue: "$US",
+		},
+		{
+			currency:      "usd",
+			lang:          "hu",
+			expectedValue: "USD",
+		},
+		{
+			currency:      "usd",
+			lang:          "fi",
+			country:       "fi",
+			expectedValue: "US$",
+		},
+		{
+			currency:      "usd",
+			expectedValue: "US$",
+		},
+		{
+			currency:      "huf",
+			lang:          "hu",
+			country:       "hu",
+			expectedValue: "Ft",
+		},
+		{
+			currency:      "huf",
+			lang:          "en",
+			expectedValue: "HUF",
+		},
+		{
+			currency:      "eur",
+			lang:          "de",
+			country:       "at",
+			expectedValue: "€",
+		},
+		{
+			currency:      "foo",
+			lang:          "en",
+			expectedValue: "FOO",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v/%v-%v -> %v", index+1, test.currency, test.lang, test.country, test.expectedValue), func(t *testing.T) {
+			result := test.currency.Symbol(test.lang, test.country)
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCurrencyNarrowSymbol(t *testing.T) {
+	for index, test := range []struct {
+		currency      Currency
+		expectedValue string
+	}{
+		{
+			currency:      "",
+			expectedValue: "",
+		},
+		{
+			currency:      "usd",
+			expectedValue: "$",
+		},
+		{
+			currency:      "huf",
+			expectedValue: "Ft",
+		},
+		{
+			currency:      "chf",
+			expectedValue: "CHF",
+		},
+		{
+			currency:      "foo",
+			expectedValue: "FOO",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.currency, test.expectedValue), func(t *testing.T) {
+			result := test.currency.NarrowSymbol()
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,416 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+var decimalValidator = regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?$`)
+
+var bigTen = big.NewInt(10)
+
+// RoundingMode tells how to round a decimal to fewer digits.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest neighbour, ties away from zero (commercial rounding).
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest neighbour, ties to the even neighbour (bankers' rounding).
+	RoundHalfEven
+	// RoundHalfDown rounds to the nearest neighbour, ties towards zero.
+	RoundHalfDown
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundDown rounds towards zero (truncation).
+	RoundDown
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+)
+
+// DecimalJSONFormat tells how Decimal is marshalled to JSON, see: SetDecimalJSONFormat.
+type DecimalJSONFormat int32
+
+const (
+	// DecimalJSONString marshals decimals as JSON strings, eg. "1.50", this is the default.
+	DecimalJSONString DecimalJSONFormat = iota
+	// DecimalJSONNumber marshals decimals as JSON numbers, eg. 1.50, which some decoders parse into floats.
+	DecimalJSONNumber
+)
+
+var decimalJSONFormat int32
+
+// SetDecimalJSONFormat sets how Decimal is marshalled to JSON. Unmarshalling accepts both formats.
+func SetDecimalJSONFormat(format DecimalJSONFormat) {
+	atomic.StoreInt32(&decimalJSONFormat, int32(format))
+}
+
+// Decimal is an exact, arbitrary precision decimal number. The zero value is 0.
+// Decimals are immutable, compare them with Cmp or Equal, as == compares pointers.
+type Decimal struct {
+	value *big.Int
+	scale int32
+}
+
+// NewDecimal parses a decimal in plain notation, eg. "-12.50". Exponents, hexadecimal, NaN and Inf are rejected.
+func NewDecimal(s string) (Decimal, error) {
+	if !decimalValidator.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal: %s", s)
+	}
+
+	digits := s
+	scale := 0
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		digits = s[:i] + s[i+1:]
+		scale = len(s) - i - 1
+	}
+
+	if scale > 1<<31-1 {
+		return Decimal{}, fmt.Errorf("invalid decimal: too many decimals: %s", s)
+	}
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %s", s)
+	}
+
+	return Decimal{value: value, scale: int32(scale)}, nil
+}
+
+// MustDecimal is NewDecimal, panicking on error. Intended for constants.
+func MustDecimal(s string) Decimal {
+	d, err := NewDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+// NewDecimalFromInt returns value * 10^-scale, eg. NewDecimalFromInt(1250, 2) is 12.50.
+func NewDecimalFromInt(value int64, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{value: new(big.Int).Mul(big.NewInt(value), pow10(-scale))}
+	}
+
+	return Decimal{value: big.NewInt(value), scale: scale}
+}
+
+// NewDecimalFromBigInt returns value * 10^-scale.
+func NewDecimalFromBigInt(value *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		return Decimal{value: new(big.Int).Mul(value, pow10(-scale))}
+	}
+
+	return Decimal{value: new(big.Int).Set(value), scale: scale}
+}
+
+// NewDecimalFromRat rounds r to scale decimals. A negative scale rounds to tens, hundreds, etc. with scale 0, like
+// Round.
+func NewDecimalFromRat(r *big.Rat, scale int32, mode RoundingMode) Decimal {
+	if scale < 0 {
+		value := roundQuotient(r.Num(), new(big.Int).Mul(r.Denom(), pow10(-scale)), mode)
+
+		return Decimal{value: value.Mul(value, pow10(-scale))}
+	}
+
+	num := new(big.Int).Mul(r.Num(), pow10(scale))
+
+	return Decimal{value: roundQuotient(num, r.Denom(), mode), scale: scale}
+}
+
+func (d Decimal) String() string {
+	value := d.unscaled()
+
+	digits := new(big.Int).Abs(value).String()
+	if d.scale > 0 {
+		if len(digits) <= int(d.scale) {
+			digits = strings.Repeat("0", int(d.scale)-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-int(d.scale)] + "." + digits[len(digits)-int(d.scale):]
+	}
+
+	if value.Sign() < 0 {
+		return "-" + digits
+	}
+
+	return digits
+}
+
+// Scale returns the number of decimals.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Unscaled returns the value without the decimal point, d is Unscaled() * 10^-Scale().
+func (d Decimal) Unscaled() *big.Int {
+	return new(big.Int).Set(d.unscaled())
+}
+
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.unscaled(), pow10(d.scale))
+}
+
+func (d Decimal) Sign() int {
+	return d.unscaled().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or 1 if d is less than, equal to or greater than other. The scale is ignored, 1.0 equals 1.00.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := align(d, other)
+
+	return a.Cmp(b)
+}
+
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{value: new(big.Int).Neg(d.unscaled()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{value: new(big.Int).Abs(d.unscaled()), scale: d.scale}
+}
+
+// Add returns d + other, with the larger scale of the two.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := align(d, other)
+
+	return Decimal{value: a.Add(a, b), scale: maxScale(d, other)}
+}
+
+// Sub returns d - other, with the larger scale of the two.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b := align(d, other)
+
+	return Decimal{value: a.Sub(a, b), scale: maxScale(d, other)}
+}
+
+// Mul returns d * other, with the sum of the scales.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{value: new(big.Int).Mul(d.unscaled(), other.unscaled()), scale: d.scale + other.scale}
+}
+
+// Div returns d / other rounded to scale decimals. A negative scale rounds to tens, hundreds, etc. with scale 0, like
+// Round.
+func (d Decimal) Div(other Decimal, scale int32, mode RoundingMode) (Decimal, error) {
+	if other.IsZero() {
+		return Decimal{}, fmt.Errorf("decimal division by zero: %s / %s", d, other)
+	}
+
+	// d / other = (dv / 10^ds) / (ov / 10^os) = dv * 10^(os-ds) / ov
+	num := new(big.Int).Set(d.unscaled())
+	den := new(big.Int).Set(other.unscaled())
+	if exp := other.scale - d.scale + scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+
+	value := roundQuotient(num, den, mode)
+	if scale < 0 {
+		return Decimal{value: value.Mul(value, pow10(-scale))}, nil
+	}
+
+	return Decimal{value: value, scale: scale}, nil
+}
+
+// Round returns d with scale decimals, rounded with mode if it had more, padded with zeros if it had less.
+// A negative scale rounds to tens, hundreds, etc. with scale 0, eg. 125 rounded to -1 is 130.
+func (d Decimal) Round(scale int32, mode RoundingMode) Decimal {
+	if scale >= d.scale {
+		return Decimal{value: new(big.Int).Mul(d.unscaled(), pow10(scale-d.scale)), scale: scale}
+	}
+
+	value := roundQuotient(d.unscaled(), pow10(d.scale-scale), mode)
+	if scale < 0 {
+		return Decimal{value: value.Mul(value, pow10(-scale))}
+	}
+
+	return Decimal{value: value, scale: scale}
+}
+
+// Normalize removes trailing zero decimals, eg. 1.500 becomes 1.5.
+func (d Decimal) Normalize() Decimal {
+	value, scale := new(big.Int).Set(d.unscaled()), d.scale
+	rem := new(big.Int)
+	for scale > 0 {
+		quo, _ := new(big.Int).QuoRem(value, bigTen, rem)
+		if rem.Sign() != 0 {
+			break
+		}
+		value, scale = quo, scale-1
+	}
+
+	return Decimal{value: value, scale: scale}
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(b []byte) error {
+	decimal, err := NewDecimal(string(b))
+	if err != nil {
+		return err
+	}
+
+	*d = decimal
+
+	return nil
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if DecimalJSONFormat(atomic.LoadInt32(&decimalJSONFormat)) == DecimalJSONNumber {
+		return []byte(d.String()), nil
+	}
+
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	str := string(b)
+	if strings.HasPrefix(str, `"`) {
+		var err error
+		if str, err = strconv.Unquote(str); err != nil {
+			return err
+		}
+	}
+
+	decimal, err := NewDecimal(str)
+	if err != nil {
+		return err
+	}
+
+	*d = decimal
+
+	return nil
+}
+
+func (d Decimal) MarshalBinary() ([]byte, error) {
+	return d.MarshalText()
+}
+
+func (d *Decimal) UnmarshalBinary(b []byte) error {
+	return d.UnmarshalText(b)
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d *Decimal) Scan(src interface{}) error {
+	var err error
+
+	switch src := src.(type) {
+	case nil:
+		*d = Decimal{}
+	case string:
+		*d, err = NewDecimal(src)
+	case []byte:
+		*d, err = NewDecimal(string(src))
+	case int64:
+		*d = NewDecimalFromInt(src, 0)
+	case float64:
+		*d, err = NewDecimal(strconv.FormatFloat(src, 'f', -1, 64))
+	default:
+		err = fmt.Errorf("cannot convert %T to Decimal", src)
+	}
+
+	return err
+}
+
+func (d Decimal) unscaled() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+
+	return d.value
+}
+
+// align returns the unscaled values of a and b with the same scale, the results can be modified.
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	av, bv := new(big.Int).Set(a.unscaled()), new(big.Int).Set(b.unscaled())
+
+	switch {
+	case a.scale > b.scale:
+		bv.Mul(bv, pow10(a.scale-b.scale))
+	case a.scale < b.scale:
+		av.Mul(av, pow10(b.scale-a.scale))
+	}
+
+	return av, bv
+}
+
+func maxScale(a, b Decimal) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+
+	return b.scale
+}
+
+func pow10(n int32) *big.Int {
+	if n < 0 {
+		panic(fmt.Sprintf("types: negative decimal scale: %d", n))
+	}
+
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// roundQuotient returns num / den rounded to an integer with mode.
+func roundQuotient(num, den *big.Int, mode RoundingMode) *big.Int {
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() == 0 {
+		return quo
+	}
+
+	// direction away from zero
+	sign := int64(num.Sign() * den.Sign())
+
+	// compare the remainder to half of the denominator
+	half := new(big.Int).Abs(rem)
+	half.Lsh(half, 1)
+	cmpHalf := half.Cmp(new(big.Int).Abs(den))
+
+	var away bool
+	switch mode {
+	case RoundUp:
+		away = true
+	case RoundDown:
+		away = false
+	case RoundCeiling:
+		away = sign > 0
+	case RoundFloor:
+		away = sign < 0
+	case RoundHalfDown:
+		away = cmpHalf > 0
+	case RoundHalfEven:
+		away = cmpHalf > 0 || (cmpHalf == 0 && quo.Bit(0) == 1)
+	default:
+		away = cmpHalf >= 0
+	}
+
+	if away {
+		quo.Add(quo, big.NewInt(sign))
+	}
+
+	return quo
+}
@@ -0,0 +1,323 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DecimalRange is a range of decimals with inclusive, exclusive or unbounded ends, like the PostgreSQL numrange.
+// The zero value is the empty range.
+type DecimalRange struct {
+	lower, upper       Decimal
+	hasLower, hasUpper bool
+	lowerInc, upperInc bool
+	nonEmpty           bool
+}
+
+// NewDecimalRange parses a range in PostgreSQL range syntax, eg. "[10.00,50.00)", "(,50]" or "empty".
+func NewDecimalRange(s string) (DecimalRange, error) {
+	str := strings.TrimSpace(s)
+	if strings.EqualFold(str, "empty") {
+		return DecimalRange{}, nil
+	}
+
+	if len(str) < 3 || (str[0] != '[' && str[0] != '(') || (str[len(str)-1] != ']' && str[len(str)-1] != ')') {
+		return DecimalRange{}, fmt.Errorf("invalid decimal range: %s", s)
+	}
+
+	bounds := strings.Split(str[1:len(str)-1], ",")
+	if len(bounds) != 2 {
+		return DecimalRange{}, fmt.Errorf("invalid decimal range: %s", s)
+	}
+
+	var lower, upper *Decimal
+	for i, bound := range bounds {
+		bound = strings.Trim(strings.TrimSpace(bound), `"`)
+		if bound == "" {
+			continue
+		}
+
+		d, err := NewDecimal(bound)
+		if err != nil {
+			return DecimalRange{}, fmt.Errorf("invalid decimal range: %s", s)
+		}
+		if i == 0 {
+			lower = &d
+		} else {
+			upper = &d
+		}
+	}
+
+	return NewDecimalRangeFromBounds(lower, upper, str[:1]+str[len(str)-1:])
+}
+
+// NewDecimalRangeFromBounds returns the range between lower and upper, nil bounds are unbounded.
+// Bounds tells which ends are inclusive, like in PostgreSQL: "[)", "[]", "()" or "(]".
+func NewDecimalRangeFromBounds(lower, upper *Decimal, bounds string) (DecimalRange, error) {
+	if len(bounds) != 2 || (bounds[0] != '[' && bounds[0] != '(') || (bounds[1] != ']' && bounds[1] != ')') {
+		return DecimalRange{}, fmt.Errorf("invalid decimal range bounds: %s", bounds)
+	}
+
+	r := DecimalRange{
+		hasLower: lower != nil,
+		hasUpper: upper != nil,
+		lowerInc: lower != nil && bounds[0] == '[',
+		upperInc: upper != nil && bounds[1] == ']',
+		nonEmpty: true,
+	}
+	if lower != nil {
+		r.lower = *lower
+	}
+	if upper != nil {
+		r.upper = *upper
+	}
+
+	if r.hasLower && r.hasUpper {
+		switch c := r.lower.Cmp(r.upper); {
+		case c > 0:
+			return DecimalRange{}, fmt.Errorf("invalid decimal range: lower bound %s is greater than upper bound %s", r.lower, r.upper)
+		case c == 0 && !(r.lowerInc && r.upperInc):
+			return DecimalRange{}, nil
+		}
+	}
+
+	return r, nil
+}
+
+func (r DecimalRange) String() string {
+	if !r.nonEmpty {
+		return "empty"
+	}
+
+	var b strings.Builder
+	if r.lowerInc {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if r.hasLower {
+		b.WriteString(r.lower.String())
+	}
+	b.WriteByte(',')
+	if r.hasUpper {
+		b.WriteString(r.upper.String())
+	}
+	if r.upperInc {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+
+	return b.String()
+}
+
+func (r DecimalRange) IsEmpty() bool {
+	return !r.nonEmpty
+}
+
+// Lower returns the lower bound, ok is false if the range is empty or unbounded below.
+func (r DecimalRange) Lower() (lower Decimal, ok bool) {
+	return r.lower, r.nonEmpty && r.hasLower
+}
+
+// Upper returns the upper bound, ok is false if the range is empty or unbounded above.
+func (r DecimalRange) Upper() (upper Decimal, ok bool) {
+	return r.upper, r.nonEmpty && r.hasUpper
+}
+
+func (r DecimalRange) LowerInclusive() bool {
+	return r.lowerInc
+}
+
+func (r DecimalRange) UpperInclusive() bool {
+	return r.upperInc
+}
+
+func (r DecimalRange) Contains(d Decimal) bool {
+	if !r.nonEmpty {
+		return false
+	}
+	if r.hasLower {
+		if c := d.Cmp(r.lower); c < 0 || (c == 0 && !r.lowerInc) {
+			return false
+		}
+	}
+	if r.hasUpper {
+		if c := d.Cmp(r.upper); c > 0 || (c == 0 && !r.upperInc) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r DecimalRange) Overlaps(other DecimalRange) bool {
+	return r.nonEmpty && other.nonEmpty && lowerBeforeUpper(r, other) && lowerBeforeUpper(other, r)
+}
+
+// Intersect returns the range contained by both r and other, which is empty if they do not overlap.
+func (r DecimalRange) Intersect(other DecimalRange) DecimalRange {
+	if !r.Overlaps(other) {
+		return DecimalRange{}
+	}
+
+	result := r
+	if cmpLower(other, r) > 0 {
+		result.lower, result.hasLower, result.lowerInc = other.lower, other.hasLower, other.lowerInc
+	}
+	if cmpUpper(other, r) < 0 {
+		result.upper, result.hasUpper, result.upperInc = other.upper, other.hasUpper, other.upperInc
+	}
+
+	return result
+}
+
+// Union returns the smallest range containing both r and other, which must overlap or be adjacent.
+func (r DecimalRange) Union(other DecimalRange) (DecimalRange, error) {
+	switch {
+	case !r.nonEmpty:
+		return other, nil
+	case !other.nonEmpty:
+		return r, nil
+	case !r.Overlaps(other) && !adjacent(r, other) && !adjacent(other, r):
+		return DecimalRange{}, fmt.Errorf("union of decimal ranges is not contiguous: %s + %s", r, other)
+	}
+
+	result := r
+	if cmpLower(other, r) < 0 {
+		result.lower, result.hasLower, result.lowerInc = other.lower, other.hasLower, other.lowerInc
+	}
+	if cmpUpper(other, r) > 0 {
+		result.upper, result.hasUpper, result.upperInc = other.upper, other.hasUpper, other.upperInc
+	}
+
+	return result, nil
+}
+
+func (r DecimalRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *DecimalRange) UnmarshalText(b []byte) error {
+	decimalRange, err := NewDecimalRange(string(b))
+	if err != nil {
+		return err
+	}
+
+	*r = decimalRange
+
+	return nil
+}
+
+func (r DecimalRange) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+func (r *DecimalRange) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+
+	return r.UnmarshalText([]byte(str))
+}
+
+func (r DecimalRange) MarshalBinary() ([]byte, error) {
+	return r.MarshalText()
+}
+
+func (r *DecimalRange) UnmarshalBinary(b []byte) error {
+	return r.UnmarshalText(b)
+}
+
+// Value returns the range as a PostgreSQL numrange literal.
+func (r DecimalRange) Value() (driver.Value, error) {
+	return r.String(), nil
+}
+
+func (r *DecimalRange) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		*r = DecimalRange{}
+		return nil
+	case string:
+		return r.UnmarshalText([]byte(src))
+	case []byte:
+		return r.UnmarshalText(src)
+	default:
+		return fmt.Errorf("cannot convert %T to DecimalRange", src)
+	}
+}
+
+// cmpLower compares the lower bounds of non-empty ranges, unbounded is the lowest.
+func cmpLower(a, b DecimalRange) int {
+	switch {
+	case !a.hasLower && !b.hasLower:
+		return 0
+	case !a.hasLower:
+		return -1
+	case !b.hasLower:
+		return 1
+	}
+
+	if c := a.lower.Cmp(b.lower); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.lowerInc == b.lowerInc:
+		return 0
+	case a.lowerInc:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// cmpUpper compares the upper bounds of non-empty ranges, unbounded is the highest.
+func cmpUpper(a, b DecimalRange) int {
+	switch {
+	case !a.hasUpper && !b.hasUpper:
+		return 0
+	case !a.hasUpper:
+		return 1
+	case !b.hasUpper:
+		return -1
+	}
+
+	if c := a.upper.Cmp(b.upper); c != 0 {
+		return c
+	}
+
+	switch {
+	case a.upperInc == b.upperInc:
+		return 0
+	case a.upperInc:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// lowerBeforeUpper tells if some value is above the lower bound of a and below the upper bound of b.
+func lowerBeforeUpper(a, b DecimalRange) bool {
+	if !a.hasLower || !b.hasUpper {
+		return true
+	}
+
+	c := a.lower.Cmp(b.upper)
+
+	return c < 0 || (c == 0 && a.lowerInc && b.upperInc)
+}
+
+// adjacent tells if a ends exactly where b begins, eg. [10,20) and [20,30).
+func adjacent(a, b DecimalRange) bool {
+	return a.hasUpper && b.hasLower && a.upper.Cmp(b.lower) == 0 && a.upperInc != b.lowerInc
+}
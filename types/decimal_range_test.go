@@ -0,0 +1,308 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDecimalRangeNew(t *testing.T) {
+	for index, test := range []struct {
+		text          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			text:          "[10.00,50.00)",
+			expectedValue: "[10.00,50.00)",
+		},
+		{
+			text:          ` ( "1" , "2" ] `,
+			expectedValue: "(1,2]",
+		},
+		{
+			text:          "[,50]",
+			expectedValue: "(,50]",
+		},
+		{
+			text:          "[10,]",
+			expectedValue: "[10,)",
+		},
+		{
+			text:          "(,)",
+			expectedValue: "(,)",
+		},
+		{
+			text:          "[5,5]",
+			expectedValue: "[5,5]",
+		},
+		{
+			text:          "[5,5)",
+			expectedValue: "empty",
+		},
+		{
+			text:          "EMPTY",
+			expectedValue: "empty",
+		},
+		{
+			text:          "[50,10)",
+			expectedError: "greater than upper bound",
+		},
+		{
+			text:          "[10;50)",
+			expectedError: "invalid decimal range",
+		},
+		{
+			text:          "10,50",
+			expectedError: "invalid decimal range",
+		},
+		{
+			text:          "[a,b)",
+			expectedError: "invalid decimal range",
+		},
+		{
+			text:          "",
+			expectedError: "invalid decimal range",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
+			result, err := NewDecimalRange(test.text)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDecimalRangeContains(t *testing.T) {
+	for index, test := range []struct {
+		r             string
+		d             string
+		expectedValue bool
+	}{
+		{r: "[10,50)", d: "10", expectedValue: true},
+		{r: "[10,50)", d: "49.99", expectedValue: true},
+		{r: "[10,50)", d: "50", expectedValue: false},
+		{r: "(10,50]", d: "10.00", expectedValue: false},
+		{r: "(10,50]", d: "50.00", expectedValue: true},
+		{r: "(,50]", d: "-1000", expectedValue: true},
+		{r: "[10,)", d: "1000000", expectedValue: true},
+		{r: "empty", d: "0", expectedValue: false},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v @> %v -> %v", index+1, test.r, test.d, test.expectedValue), func(t *testing.T) {
+			if result := mustDecimalRange(t, test.r).Contains(MustDecimal(test.d)); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDecimalRangeOperations(t *testing.T) {
+	for index, test := range []struct {
+		a                 string
+		b                 string
+		expectedOverlaps  bool
+		expectedIntersect string
+		expectedUnion     string
+		expectedError     string
+	}{
+		{
+			a:                 "[10,50)",
+			b:                 "[30,80)",
+			expectedOverlaps:  true,
+			expectedIntersect: "[30,50)",
+			expectedUnion:     "[10,80)",
+		},
+		{
+			a:                 "[10,50)",
+			b:                 "[50,80)",
+			expectedOverlaps:  false,
+			expectedIntersect: "empty",
+			expectedUnion:     "[10,80)",
+		},
+		{
+			a:                 "[10,50]",
+			b:                 "[50,80)",
+			expectedOverlaps:  true,
+			expectedIntersect: "[50,50]",
+			expectedUnion:     "[10,80)",
+		},
+		{
+			a:                 "(,50)",
+			b:                 "(20,)",
+			expectedOverlaps:  true,
+			expectedIntersect: "(20,50)",
+			expectedUnion:     "(,)",
+		},
+		{
+			a:                 "[10,20)",
+			b:                 "[10,20]",
+			expectedOverlaps:  true,
+			expectedIntersect: "[10,20)",
+			expectedUnion:     "[10,20]",
+		},
+		{
+			a:                 "empty",
+			b:                 "[1,2)",
+			expectedOverlaps:  false,
+			expectedIntersect: "empty",
+			expectedUnion:     "[1,2)",
+		},
+		{
+			a:                 "[10,20)",
+			b:                 "(20,30)",
+			expectedOverlaps:  false,
+			expectedIntersect: "empty",
+			expectedError:     "not contiguous",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v, %v", index+1, test.a, test.b), func(t *testing.T) {
+			a, b := mustDecimalRange(t, test.a), mustDecimalRange(t, test.b)
+
+			if result := a.Overlaps(b); result != test.expectedOverlaps {
+				t.Errorf("overlaps: expected: %v, got: %v", test.expectedOverlaps, result)
+			}
+			if result := b.Overlaps(a); result != test.expectedOverlaps {
+				t.Errorf("reverse overlaps: expected: %v, got: %v", test.expectedOverlaps, result)
+			}
+			if result := a.Intersect(b); result.String() != test.expectedIntersect {
+				t.Errorf("intersect: expected: %v, got: %v", test.expectedIntersect, result)
+			}
+			if result := b.Intersect(a); result.String() != test.expectedIntersect {
+				t.Errorf("reverse intersect: expected: %v, got: %v", test.expectedIntersect, result)
+			}
+
+			for _, pair := range [][2]DecimalRange{{a, b}, {b, a}} {
+				result, err := pair[0].Union(pair[1])
+				if err != nil {
+					if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+						continue
+					}
+					t.Fatal(err)
+				} else if test.expectedError != "" {
+					t.Errorf("expected error: %s, got none", test.expectedError)
+				}
+				if result.String() != test.expectedUnion {
+					t.Errorf("union: expected: %v, got: %v", test.expectedUnion, result)
+				}
+			}
+		})
+	}
+}
+
+func TestDecimalRangeJSON(t *testing.T) {
+	for index, test := range []struct {
+		json          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			json:          `"[10.00,50.00)"`,
+			expectedValue: `"[10.00,50.00)"`,
+		},
+		{
+			json:          `null`,
+			expectedValue: `"empty"`,
+		},
+		{
+			json:          `10`,
+			expectedError: "invalid syntax",
+		},
+		{
+			json:          `"[10,50"`,
+			expectedError: "invalid decimal range",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.json, test.expectedValue), func(t *testing.T) {
+			var r DecimalRange
+			err := json.Unmarshal([]byte(test.json), &r)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, string(b))
+			}
+		})
+	}
+}
+
+func TestDecimalRangeSql(t *testing.T) {
+	for index, test := range []struct {
+		src           interface{}
+		expectedValue string
+		expectedError string
+	}{
+		{
+			src:           nil,
+			expectedValue: "empty",
+		},
+		{
+			src:           "empty",
+			expectedValue: "empty",
+		},
+		{
+			src:           "[10.00,50.00)",
+			expectedValue: "[10.00,50.00)",
+		},
+		{
+			src:           []byte(`("10.5",)`),
+			expectedValue: "(10.5,)",
+		},
+		{
+			src:           int64(1),
+			expectedError: "cannot convert int64 to DecimalRange",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			var scanValue DecimalRange
+			err := scanValue.Scan(test.src)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			driverValue, err := scanValue.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if driverValue != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, driverValue)
+			}
+		})
+	}
+}
+
+func mustDecimalRange(t *testing.T, s string) DecimalRange {
+	t.Helper()
+
+	r, err := NewDecimalRange(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
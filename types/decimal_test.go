@@ -0,0 +1,474 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestDecimalNew(t *testing.T) {
+	for index, test := range []struct {
+		text          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			text:          "0",
+			expectedValue: "0",
+		},
+		{
+			text:          "12.50",
+			expectedValue: "12.50",
+		},
+		{
+			text:          "-0.005",
+			expectedValue: "-0.005",
+		},
+		{
+			text:          "+7",
+			expectedValue: "7",
+		},
+		{
+			text:          "123456789012345678901234567890.123456789012345678901234567890",
+			expectedValue: "123456789012345678901234567890.123456789012345678901234567890",
+		},
+		{
+			text:          "",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "1e5",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "1.5E-3",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "0x10",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "NaN",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "Inf",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "1.",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          ".5",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          "1_000",
+			expectedError: "invalid decimal",
+		},
+		{
+			text:          " 1",
+			expectedError: "invalid decimal",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
+			result, err := NewDecimal(test.text)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDecimalString(t *testing.T) {
+	for index, test := range []struct {
+		decimal       Decimal
+		expectedValue string
+	}{
+		{
+			decimal:       Decimal{},
+			expectedValue: "0",
+		},
+		{
+			decimal:       NewDecimalFromInt(1250, 2),
+			expectedValue: "12.50",
+		},
+		{
+			decimal:       NewDecimalFromInt(-5, 3),
+			expectedValue: "-0.005",
+		},
+		{
+			decimal:       NewDecimalFromInt(12, -2),
+			expectedValue: "1200",
+		},
+		{
+			decimal:       NewDecimalFromBigInt(big.NewInt(1), 20),
+			expectedValue: "0.00000000000000000001",
+		},
+		{
+			decimal:       MustDecimal("1.500").Normalize(),
+			expectedValue: "1.5",
+		},
+		{
+			decimal:       MustDecimal("100").Normalize(),
+			expectedValue: "100",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test.expectedValue), func(t *testing.T) {
+			result := test.decimal.String()
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	modes := []RoundingMode{RoundHalfUp, RoundHalfEven, RoundHalfDown, RoundUp, RoundDown, RoundCeiling, RoundFloor}
+
+	for index, test := range []struct {
+		text           string
+		scale          int32
+		expectedValues []string
+	}{
+		//                                    half up, half even, half down, up, down, ceiling, floor
+		{text: "2.5", scale: 0, expectedValues: []string{"3", "2", "2", "3", "2", "3", "2"}},
+		{text: "3.5", scale: 0, expectedValues: []string{"4", "4", "3", "4", "3", "4", "3"}},
+		{text: "-2.5", scale: 0, expectedValues: []string{"-3", "-2", "-2", "-3", "-2", "-2", "-3"}},
+		{text: "2.51", scale: 0, expectedValues: []string{"3", "3", "3", "3", "2", "3", "2"}},
+		{text: "-2.49", scale: 0, expectedValues: []string{"-2", "-2", "-2", "-3", "-2", "-2", "-3"}},
+		{text: "1.005", scale: 2, expectedValues: []string{"1.01", "1.00", "1.00", "1.01", "1.00", "1.01", "1.00"}},
+		{text: "1.2", scale: 0, expectedValues: []string{"1", "1", "1", "2", "1", "2", "1"}},
+		{text: "2", scale: 0, expectedValues: []string{"2", "2", "2", "2", "2", "2", "2"}},
+		{text: "1.5", scale: 3, expectedValues: []string{"1.500", "1.500", "1.500", "1.500", "1.500", "1.500", "1.500"}},
+		{text: "-0.004", scale: 2, expectedValues: []string{"0.00", "0.00", "0.00", "-0.01", "0.00", "0.00", "-0.01"}},
+		{text: "125", scale: -1, expectedValues: []string{"130", "120", "120", "130", "120", "130", "120"}},
+		{text: "-125.5", scale: -2, expectedValues: []string{"-100", "-100", "-100", "-200", "-100", "-100", "-200"}},
+		{text: "1500.00", scale: -3, expectedValues: []string{"2000", "2000", "1000", "2000", "1000", "2000", "1000"}},
+	} {
+		for i, mode := range modes {
+			t.Run(fmt.Sprintf("Case %d/%d: %v -> %v", index+1, mode, test.text, test.expectedValues[i]), func(t *testing.T) {
+				result := MustDecimal(test.text).Round(test.scale, mode).String()
+				if result != test.expectedValues[i] {
+					t.Fatalf("expected: %v, got: %v", test.expectedValues[i], result)
+				}
+			})
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	for index, test := range []struct {
+		a, b        string
+		expectedAdd string
+		expectedSub string
+		expectedMul string
+		expectedDiv string
+		expectedCmp int
+	}{
+		{
+			a:           "0.1",
+			b:           "0.2",
+			expectedAdd: "0.3",
+			expectedSub: "-0.1",
+			expectedMul: "0.02",
+			expectedDiv: "0.5000",
+			expectedCmp: -1,
+		},
+		{
+			a:           "10",
+			b:           "3",
+			expectedAdd: "13",
+			expectedSub: "7",
+			expectedMul: "30",
+			expectedDiv: "3.3333",
+			expectedCmp: 1,
+		},
+		{
+			a:           "1.00",
+			b:           "1",
+			expectedAdd: "2.00",
+			expectedSub: "0.00",
+			expectedMul: "1.00",
+			expectedDiv: "1.0000",
+			expectedCmp: 0,
+		},
+		{
+			a:           "-2",
+			b:           "3",
+			expectedAdd: "1",
+			expectedSub: "-5",
+			expectedMul: "-6",
+			expectedDiv: "-0.6667",
+			expectedCmp: -1,
+		},
+		{
+			a:           "1",
+			b:           "0.00001",
+			expectedAdd: "1.00001",
+			expectedSub: "0.99999",
+			expectedMul: "0.00001",
+			expectedDiv: "100000.0000",
+			expectedCmp: 1,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v, %v", index+1, test.a, test.b), func(t *testing.T) {
+			a, b := MustDecimal(test.a), MustDecimal(test.b)
+
+			if result := a.Add(b).String(); result != test.expectedAdd {
+				t.Fatalf("add expected: %v, got: %v", test.expectedAdd, result)
+			}
+			if result := a.Sub(b).String(); result != test.expectedSub {
+				t.Fatalf("sub expected: %v, got: %v", test.expectedSub, result)
+			}
+			if result := a.Mul(b).String(); result != test.expectedMul {
+				t.Fatalf("mul expected: %v, got: %v", test.expectedMul, result)
+			}
+			div, err := a.Div(b, 4, RoundHalfUp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := div.String(); result != test.expectedDiv {
+				t.Fatalf("div expected: %v, got: %v", test.expectedDiv, result)
+			}
+			if result := a.Cmp(b); result != test.expectedCmp {
+				t.Fatalf("cmp expected: %v, got: %v", test.expectedCmp, result)
+			}
+			if a.String() != test.a || b.String() != test.b {
+				t.Fatalf("operands changed: %v, %v", a, b)
+			}
+		})
+	}
+
+	if _, err := MustDecimal("1").Div(Decimal{}, 2, RoundHalfUp); err == nil || !strings.Contains(err.Error(), "division by zero") {
+		t.Fatalf("expected error: division by zero, got: %v", err)
+	}
+}
+
+func TestDecimalDivScale(t *testing.T) {
+	for index, test := range []struct {
+		a, b          string
+		scale         int32
+		expectedValue string
+		expectedScale int32
+	}{
+		{a: "100", b: "3", scale: 2, expectedValue: "33.33", expectedScale: 2},
+		{a: "100", b: "3", scale: 0, expectedValue: "33", expectedScale: 0},
+		{a: "100", b: "3", scale: -1, expectedValue: "30", expectedScale: 0},
+		{a: "-2500", b: "0.2", scale: -3, expectedValue: "-13000", expectedScale: 0},
+		{a: "1", b: "3", scale: -1, expectedValue: "0", expectedScale: 0},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v / %v -> %v", index+1, test.a, test.b, test.expectedValue), func(t *testing.T) {
+			result, err := MustDecimal(test.a).Div(MustDecimal(test.b), test.scale, RoundHalfUp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.String() != test.expectedValue || result.Scale() != test.expectedScale {
+				t.Fatalf("expected: %v (scale %d), got: %v (scale %d)", test.expectedValue, test.expectedScale, result, result.Scale())
+			}
+			if result.Cmp(MustDecimal(test.expectedValue)) != 0 {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDecimalFromRat(t *testing.T) {
+	for index, test := range []struct {
+		rat           *big.Rat
+		scale         int32
+		expectedValue string
+	}{
+		{rat: big.NewRat(2, 3), scale: 2, expectedValue: "0.67"},
+		{rat: big.NewRat(-2, 3), scale: 0, expectedValue: "-1"},
+		{rat: big.NewRat(1250, 1), scale: -2, expectedValue: "1300"},
+		{rat: big.NewRat(-1, 3), scale: -1, expectedValue: "0"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.rat, test.expectedValue), func(t *testing.T) {
+			result := NewDecimalFromRat(test.rat, test.scale, RoundHalfUp)
+			if result.String() != test.expectedValue || result.Cmp(MustDecimal(test.expectedValue)) != 0 {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+
+	if rat := MustDecimal("-1.25").Rat(); rat.Cmp(big.NewRat(-5, 4)) != 0 {
+		t.Fatalf("expected: -5/4, got: %v", rat)
+	}
+}
+
+func TestDecimalMsgPack(t *testing.T) {
+	for index, test := range []struct {
+		text          string
+		expectedValue string
+	}{
+		{
+			text:          "0",
+			expectedValue: "0",
+		},
+		{
+			text:          "-12.50",
+			expectedValue: "-12.50",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
+			handle := &codec.MsgpackHandle{}
+
+			var b []byte
+			err := codec.NewEncoderBytes(&b, handle).Encode(MustDecimal(test.text))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var decimal Decimal
+			err = codec.NewDecoderBytes(b, handle).Decode(&decimal)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if decimal.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, decimal)
+			}
+		})
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	for index, test := range []struct {
+		json          string
+		format        DecimalJSONFormat
+		expectedValue string
+		expectedError string
+	}{
+		{
+			json:          `"12.50"`,
+			format:        DecimalJSONString,
+			expectedValue: `"12.50"`,
+		},
+		{
+			json:          `12.50`,
+			format:        DecimalJSONString,
+			expectedValue: `"12.50"`,
+		},
+		{
+			json:          `"12.50"`,
+			format:        DecimalJSONNumber,
+			expectedValue: `12.50`,
+		},
+		{
+			json:          `null`,
+			format:        DecimalJSONString,
+			expectedValue: `"0"`,
+		},
+		{
+			json:          `1e3`,
+			expectedError: "invalid decimal",
+		},
+		{
+			json:          `"1e3"`,
+			expectedError: "invalid decimal",
+		},
+		{
+			json:          `""`,
+			expectedError: "invalid decimal",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.json, test.expectedValue), func(t *testing.T) {
+			defer SetDecimalJSONFormat(DecimalJSONString)
+			SetDecimalJSONFormat(test.format)
+
+			var decimal Decimal
+			err := json.Unmarshal([]byte(test.json), &decimal)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			b, err := json.Marshal(decimal)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, string(b))
+			}
+		})
+	}
+}
+
+func TestDecimalSql(t *testing.T) {
+	for index, test := range []struct {
+		src           interface{}
+		expectedValue string
+		expectedError string
+	}{
+		{
+			src:           nil,
+			expectedValue: "0",
+		},
+		{
+			src:           "12.50",
+			expectedValue: "12.50",
+		},
+		{
+			src:           []byte("-0.01"),
+			expectedValue: "-0.01",
+		},
+		{
+			src:           int64(42),
+			expectedValue: "42",
+		},
+		{
+			src:           0.1,
+			expectedValue: "0.1",
+		},
+		{
+			src:           "foo",
+			expectedError: "invalid decimal",
+		},
+		{
+			src:           true,
+			expectedError: "cannot convert bool to Decimal",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			var scanValue Decimal
+			err := scanValue.Scan(test.src)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			driverValue, err := scanValue.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if driverValue != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, driverValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidFormat is the reason of codes not matching the format of their type, eg. "12" as a country code.
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrUnknownCode is the reason of well formed codes that are not assigned, eg. "xy" as a country code.
+	ErrUnknownCode = errors.New("unknown code")
+	// ErrWithdrawnCode is the reason of codes that were assigned once, eg. "yu" as a country code.
+	ErrWithdrawnCode = errors.New("withdrawn code")
+)
+
+// InvalidCodeError is returned for invalid codes, use errors.Is with ErrInvalidFormat, ErrUnknownCode or
+// ErrWithdrawnCode to tell the reason.
+type InvalidCodeError struct {
+	// Kind is the name of the code type, eg. "country code"
+	Kind string
+	// Input is the code as given
+	Input string
+	// Reason is ErrInvalidFormat, ErrUnknownCode or ErrWithdrawnCode
+	Reason error
+}
+
+func (e *InvalidCodeError) Error() string {
+	if e.Reason == nil || errors.Is(e.Reason, ErrInvalidFormat) {
+		return fmt.Sprintf("invalid %s: %s", e.Kind, e.Input)
+	}
+
+	return fmt.Sprintf("invalid %s: %s: %v", e.Kind, e.Input, e.Reason)
+}
+
+func (e *InvalidCodeError) Unwrap() error {
+	return e.Reason
+}
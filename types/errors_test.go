@@ -0,0 +1,154 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestInvalidCodeError(t *testing.T) {
+	for index, test := range []struct {
+		parse           func() error
+		expectedKind    string
+		expectedInput   string
+		expectedReason  error
+		expectedMessage string
+	}{
+		{
+			parse:           func() error { _, err := NewCountryCode("12"); return err },
+			expectedKind:    "country code",
+			expectedInput:   "12",
+			expectedReason:  ErrInvalidFormat,
+			expectedMessage: "invalid country code: 12",
+		},
+		{
+			parse:           func() error { _, err := NewCurrency("Fo1"); return err },
+			expectedKind:    "currency",
+			expectedInput:   "Fo1",
+			expectedReason:  ErrInvalidFormat,
+			expectedMessage: "invalid currency: Fo1",
+		},
+		{
+			parse:           func() error { _, err := NewLanguage("Foo"); return err },
+			expectedKind:    "language",
+			expectedInput:   "Foo",
+			expectedReason:  ErrInvalidFormat,
+			expectedMessage: "invalid language: Foo",
+		},
+		{
+			parse:           func() error { _, err := NewNationality("t1"); return err },
+			expectedKind:    "nationality",
+			expectedInput:   "t1",
+			expectedReason:  ErrUnknownCode,
+			expectedMessage: "invalid nationality: t1: unknown code",
+		},
+		{
+			parse:           func() error { _, err := NewNationality("yu"); return err },
+			expectedKind:    "nationality",
+			expectedInput:   "yu",
+			expectedReason:  ErrWithdrawnCode,
+			expectedMessage: "invalid nationality: yu: withdrawn code",
+		},
+		{
+			parse:           func() error { _, err := NewTimeZone("Europe/Nowhere"); return err },
+			expectedKind:    "time zone",
+			expectedInput:   "Europe/Nowhere",
+			expectedReason:  ErrUnknownCode,
+			expectedMessage: "invalid time zone: Europe/Nowhere: unknown code",
+		},
+		{
+			parse:           func() error { var c Currency; return c.UnmarshalJSON([]byte(`"EURO"`)) },
+			expectedKind:    "currency",
+			expectedInput:   "EURO",
+			expectedReason:  ErrInvalidFormat,
+			expectedMessage: "invalid currency: EURO",
+		},
+		{
+			parse:           func() error { _, err := NewCountryCodeFromEmoji("\U0001F1ED"); return err },
+			expectedKind:    "country code",
+			expectedInput:   "\U0001F1ED",
+			expectedReason:  ErrInvalidFormat,
+			expectedMessage: "invalid country code: \U0001F1ED",
+		},
+		{
+			parse:           func() error { return CountryCode("xy").Validate() },
+			expectedKind:    "country code",
+			expectedInput:   "xy",
+			expectedReason:  ErrUnknownCode,
+			expectedMessage: "invalid country code: xy: unknown code",
+		},
+		{
+			parse:           func() error { return CountryCode("yu").Validate() },
+			expectedKind:    "country code",
+			expectedInput:   "yu",
+			expectedReason:  ErrWithdrawnCode,
+			expectedMessage: "invalid country code: yu: withdrawn code",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test.expectedMessage), func(t *testing.T) {
+			err := test.parse()
+
+			var codeErr *InvalidCodeError
+			if !errors.As(err, &codeErr) {
+				t.Fatalf("expected *InvalidCodeError, got: %T: %v", err, err)
+			}
+			if codeErr.Kind != test.expectedKind {
+				t.Errorf("expected kind: %v, got: %v", test.expectedKind, codeErr.Kind)
+			}
+			if codeErr.Input != test.expectedInput {
+				t.Errorf("expected input: %v, got: %v", test.expectedInput, codeErr.Input)
+			}
+			if !errors.Is(err, test.expectedReason) {
+				t.Errorf("expected reason: %v, got: %v", test.expectedReason, codeErr.Reason)
+			}
+			if err.Error() != test.expectedMessage {
+				t.Errorf("expected message: %v, got: %v", test.expectedMessage, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	RegisterCryptoCurrencies()
+
+	for index, test := range []struct {
+		code           interface{ Validate() error }
+		expectedReason error
+	}{
+		{code: CountryCode("")},
+		{code: CountryCode("hu")},
+		{code: CountryCode("t1")},
+		{code: CountryCode("T1")},
+		{code: CountryCode("eu")},
+		{code: CountryCode("xk")},
+		{code: CountryCode("xx"), expectedReason: ErrUnknownCode},
+		{code: CountryCode("cs"), expectedReason: ErrWithdrawnCode},
+		{code: CountryCode("123"), expectedReason: ErrInvalidFormat},
+		{code: Currency("")},
+		{code: Currency("eur")},
+		{code: Currency("btc")},
+		{code: Currency("foo"), expectedReason: ErrUnknownCode},
+		{code: Currency("dem"), expectedReason: ErrWithdrawnCode},
+		{code: Currency("bgn"), expectedReason: ErrWithdrawnCode},
+		{code: Currency("e-r"), expectedReason: ErrInvalidFormat},
+		{code: Language("")},
+		{code: Language("hu")},
+		{code: Language("he")},
+		{code: Language("iw"), expectedReason: ErrWithdrawnCode},
+		{code: Language("qq"), expectedReason: ErrUnknownCode},
+		{code: Language("h1"), expectedReason: ErrInvalidFormat},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %T %v -> %v", index+1, test.code, test.code, test.expectedReason), func(t *testing.T) {
+			err := test.code.Validate()
+			if test.expectedReason == nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if !errors.Is(err, test.expectedReason) {
+				t.Fatalf("expected: %v, got: %v", test.expectedReason, err)
+			}
+		})
+	}
+}
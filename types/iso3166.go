@@ -0,0 +1,83 @@
+package types
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// iso3166.csv lists the ISO 3166-1 countries and the withdrawn codes of ISO 3166-3, plus the exceptionally
+// reserved EU and the user-assigned XK (Kosovo)
+//
+//go:embed data/iso3166.csv
+var iso3166CSV string
+
+var (
+	iso3166Once      sync.Once
+	iso3166          map[CountryCode]countryInfo
+	countryByNumeric map[int]CountryCode
+)
+
+type countryInfo struct {
+	numeric   int
+	withdrawn bool
+}
+
+// Validate returns an *InvalidCodeError if the country code is malformed, not assigned, or withdrawn from ISO 3166-1.
+// The empty code and T1 are valid.
+func (c CountryCode) Validate() error {
+	if c == "" || strings.EqualFold(c.String(), "t1") {
+		return nil
+	}
+
+	if !(countryCodeSpec{}).ValidCode(c.String()) {
+		return &InvalidCodeError{Kind: "country code", Input: c.String(), Reason: ErrInvalidFormat}
+	}
+
+	info, ok := lookupISO3166(c)
+	switch {
+	case !ok:
+		return &InvalidCodeError{Kind: "country code", Input: c.String(), Reason: ErrUnknownCode}
+	case info.withdrawn:
+		return &InvalidCodeError{Kind: "country code", Input: c.String(), Reason: ErrWithdrawnCode}
+	}
+
+	return nil
+}
+
+func lookupISO3166(c CountryCode) (countryInfo, bool) {
+	iso3166Once.Do(loadISO3166)
+
+	info, ok := iso3166[CountryCode(strings.ToLower(c.String()))]
+
+	return info, ok
+}
+
+func loadISO3166() {
+	iso3166 = make(map[CountryCode]countryInfo)
+	countryByNumeric = make(map[int]CountryCode)
+
+	records, err := csv.NewReader(strings.NewReader(iso3166CSV)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("types: invalid embedded iso3166 table: %v", err))
+	}
+
+	for _, record := range records[1:] {
+		info := countryInfo{withdrawn: record[3] != ""}
+		if record[2] != "" {
+			if info.numeric, err = strconv.Atoi(record[2]); err != nil {
+				panic(fmt.Sprintf("types: invalid embedded iso3166 table: %v", err))
+			}
+		}
+
+		iso3166[CountryCode(record[0])] = info
+
+		// numeric codes of withdrawn countries can be reused, prefer the current country
+		if prev, ok := countryByNumeric[info.numeric]; info.numeric != 0 && (!ok || iso3166[prev].withdrawn) {
+			countryByNumeric[info.numeric] = CountryCode(record[0])
+		}
+	}
+}
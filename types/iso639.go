@@ -0,0 +1,66 @@
+package types
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// iso639.csv lists the ISO 639-1 languages, and the withdrawn codes with the year of withdrawal
+//
+//go:embed data/iso639.csv
+var iso639CSV string
+
+var (
+	iso639Once sync.Once
+	iso639     map[Language]languageInfo
+)
+
+type languageInfo struct {
+	withdrawn bool
+}
+
+// Validate returns an *InvalidCodeError if the language is malformed, not assigned, or withdrawn from ISO 639-1,
+// eg. "iw" which was replaced by "he". The empty code is valid.
+func (l Language) Validate() error {
+	if l == "" {
+		return nil
+	}
+
+	if !(languageSpec{}).ValidCode(l.String()) {
+		return &InvalidCodeError{Kind: "language", Input: l.String(), Reason: ErrInvalidFormat}
+	}
+
+	info, ok := lookupISO639(l)
+	switch {
+	case !ok:
+		return &InvalidCodeError{Kind: "language", Input: l.String(), Reason: ErrUnknownCode}
+	case info.withdrawn:
+		return &InvalidCodeError{Kind: "language", Input: l.String(), Reason: ErrWithdrawnCode}
+	}
+
+	return nil
+}
+
+func lookupISO639(l Language) (languageInfo, bool) {
+	iso639Once.Do(loadISO639)
+
+	info, ok := iso639[Language(strings.ToLower(l.String()))]
+
+	return info, ok
+}
+
+func loadISO639() {
+	iso639 = make(map[Language]languageInfo)
+
+	records, err := csv.NewReader(strings.NewReader(iso639CSV)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("types: invalid embedded iso639 table: %v", err))
+	}
+
+	for _, record := range records[1:] {
+		iso639[Language(record[0])] = languageInfo{withdrawn: record[2] != ""}
+	}
+}
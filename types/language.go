@@ -1,12 +1,12 @@
 package types
 
 import (
-	"bytes"
 	"database/sql/driver"
-	"fmt"
+	"encoding/xml"
 	"regexp"
-	"strconv"
 	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 var languageValidator = regexp.MustCompile(`^[A-Za-z]{2}$`)
@@ -14,93 +14,83 @@ var languageValidator = regexp.MustCompile(`^[A-Za-z]{2}$`)
 // ISO 639-1 representation of language langs
 type Language string
 
-func NewLanguage(lang string) (Language, error) {
-	if lang == "" {
-		return "", nil
-	}
+type languageSpec struct{}
+
+func (languageSpec) CodeName() string {
+	return "language"
+}
 
-	if !languageValidator.MatchString(lang) {
-		return "", fmt.Errorf("invalid language: %s", lang)
-	}
+func (languageSpec) ValidCode(code string) bool {
+	return languageValidator.MatchString(code)
+}
 
-	return Language(strings.ToLower(lang)), nil
+func NewLanguage(lang string) (Language, error) {
+	return parseCode[Language](languageSpec{}, lang)
 }
 
 func (l Language) String() string {
 	return string(l)
 }
 
+// Upper returns the upper-case code, eg. "DE".
+func (l Language) Upper() string {
+	return strings.ToUpper(string(l))
+}
+
 func (l Language) MarshalText() ([]byte, error) {
-	return []byte(l.String()), nil
+	return marshalCodeText(withOutputCase(l))
 }
 
 func (l *Language) UnmarshalText(b []byte) error {
-	lang, err := NewLanguage(string(b))
-	if err != nil {
-		return err
-	}
-
-	*l = lang
-
-	return nil
+	return unmarshalCodeText(l, b, NewLanguage)
 }
 
 func (l Language) MarshalJSON() ([]byte, error) {
-	if l.String() == "" {
-		return []byte("null"), nil
-	}
-
-	return []byte(strconv.Quote(l.String())), nil
+	return marshalCodeJSON(withOutputCase(l))
 }
 
 func (l *Language) UnmarshalJSON(b []byte) error {
-	if bytes.Equal(b, []byte("null")) {
-		return nil
-	}
+	return unmarshalCodeJSON(l, b, NewLanguage)
+}
 
-	str, err := strconv.Unquote(string(b))
-	if err != nil {
-		return err
-	}
+func (l Language) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(withOutputCase(l))
+}
+
+func (l *Language) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(l, node, NewLanguage)
+}
 
-	lang, err := NewLanguage(str)
-	if err != nil {
-		return err
-	}
+func (l Language) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(withXMLOutputCase(l), e, start)
+}
 
-	*l = lang
+func (l *Language) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(l, d, start, NewLanguage)
+}
 
-	return nil
+func (l Language) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(withXMLOutputCase(l), name)
 }
 
+func (l *Language) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(l, attr, NewLanguage)
+}
+
+// MarshalBinary returns the text form, or the compact form if enabled by SetBinaryCompact.
 func (l Language) MarshalBinary() ([]byte, error) {
-	return l.MarshalText()
+	return marshalCodeBinary(l, languageNumeric)
 }
 
+// UnmarshalBinary accepts both the text and the compact form.
 func (l *Language) UnmarshalBinary(b []byte) error {
-	return l.UnmarshalText(b)
+	return unmarshalCodeBinary(l, b, "language", NewLanguage, languageFromNumeric)
 }
 
 func (l Language) Value() (driver.Value, error) {
-	if l.String() == "" {
-		return nil, nil
-	}
-
-	return l.String(), nil
+	return codeValue(withOutputCase(l))
 }
 
 func (l *Language) Scan(src interface{}) error {
-	if src == nil {
-		*l = ""
-		return nil
-	}
-
-	if src, ok := src.(string); ok {
-		var err error
-		*l, err = NewLanguage(src)
-
-		return err
-	}
-
-	return fmt.Errorf("cannot convert %T to Language", src)
+	return scanCode(l, src, NewLanguage, "Language")
 }
@@ -1,275 +1,36 @@
 package types
 
 import (
-	"encoding/json"
-	"fmt"
-	"strings"
 	"testing"
 
-	"github.com/ugorji/go/codec"
+	"github.com/proemergotech/types/types/codetest"
 )
 
-func TestLanguageNew(t *testing.T) {
-	for index, test := range []struct {
-		text          string
-		expectedValue Language
-		expectedError string
-	}{
+func TestLanguage(t *testing.T) {
+	codetest.Run(t, NewLanguage, []codetest.Case{
 		{
-			text:          "",
-			expectedValue: "",
+			Text:          "",
+			ExpectedValue: "",
 		},
 		{
-			text:          "fo",
-			expectedValue: "fo",
+			Text:          "fo",
+			ExpectedValue: "fo",
 		},
 		{
-			text:          "Fo",
-			expectedValue: "fo",
+			Text:          "Fo",
+			ExpectedValue: "fo",
 		},
 		{
-			text:          "t1",
-			expectedError: "invalid language",
+			Text:          "t1",
+			ExpectedError: "invalid language",
 		},
 		{
-			text:          "Foo",
-			expectedError: "invalid language",
+			Text:          "Foo",
+			ExpectedError: "invalid language",
 		},
 		{
-			text:          "12",
-			expectedError: "invalid language",
+			Text:          "12",
+			ExpectedError: "invalid language",
 		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			result, err := NewLanguage(test.text)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-			if !strings.EqualFold(result.String(), test.expectedValue.String()) {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
-			}
-		})
-	}
-}
-
-func TestLanguageString(t *testing.T) {
-	for index, test := range []struct {
-		lang          Language
-		expectedValue string
-	}{
-		{
-			lang:          "",
-			expectedValue: "",
-		},
-		{
-			lang:          "foo",
-			expectedValue: "foo",
-		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.lang, test.expectedValue), func(t *testing.T) {
-			result := test.lang.String()
-			if result != test.expectedValue {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
-			}
-		})
-	}
-}
-
-func TestLanguageMsgPack(t *testing.T) {
-	for index, test := range []struct {
-		text          string
-		expectedValue string
-		expectedError string
-	}{
-		{
-			text:          "",
-			expectedValue: "",
-		},
-		{
-			text:          "fo",
-			expectedValue: "fo",
-		},
-		{
-			text:          "Fo",
-			expectedValue: "fo",
-		},
-		{
-			text:          "Foo",
-			expectedError: "invalid language",
-		},
-		{
-			text:          "12",
-			expectedError: "invalid language",
-		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			handle := &codec.MsgpackHandle{}
-
-			var textB []byte
-			err := codec.NewEncoderBytes(&textB, handle).Encode(test.text)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var lang Language
-			err = codec.NewDecoderBytes(textB, handle).Decode(&lang)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-
-			var b []byte
-			err = codec.NewEncoderBytes(&b, handle).Encode(&lang)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var str string
-			err = codec.NewDecoderBytes(b, handle).Decode(&str)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			if str != test.expectedValue {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, str)
-			}
-		})
-	}
-}
-
-func TestLanguageJSON(t *testing.T) {
-	for index, test := range []struct {
-		text          string
-		expectedValue string
-		expectedError string
-	}{
-		{
-			text:          "",
-			expectedValue: "",
-		},
-		{
-			text:          "fo",
-			expectedValue: "fo",
-		},
-		{
-			text:          "Fo",
-			expectedValue: "fo",
-		},
-		{
-			text:          "Foo",
-			expectedError: "invalid language",
-		},
-		{
-			text:          "12",
-			expectedError: "invalid language",
-		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			textB, err := json.Marshal(test.text)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var lang Language
-			err = json.Unmarshal(textB, &lang)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-
-			b, err := json.Marshal(lang)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			var str string
-			err = json.Unmarshal(b, &str)
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			if !strings.EqualFold(str, test.expectedValue) {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, str)
-			}
-		})
-	}
-}
-
-func TestLanguageSql(t *testing.T) {
-	for index, test := range []struct {
-		text          string
-		expectedValue string
-		expectedError string
-	}{
-		{
-			text:          "",
-			expectedValue: "",
-		},
-		{
-			text:          "fo",
-			expectedValue: "fo",
-		},
-		{
-			text:          "Fo",
-			expectedValue: "fo",
-		},
-		{
-			text:          "Foo",
-			expectedError: "invalid language",
-		},
-		{
-			text:          "12",
-			expectedError: "invalid language",
-		},
-	} {
-		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
-			origCode, err := NewLanguage(test.text)
-			if err != nil {
-				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
-					return
-				}
-				t.Fatal(err)
-			} else if test.expectedError != "" {
-				t.Errorf("expected error: %s, got none", test.expectedError)
-			}
-			driverValue, err := origCode.Value()
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			s, ok := driverValue.(string)
-			if !ok && test.text != "" {
-				t.Fatalf("value does not returned with a string, returned: %T", driverValue)
-			}
-
-			var scanValue Language
-
-			if s == "" {
-				err = scanValue.Scan(nil)
-			} else {
-				err = scanValue.Scan(s)
-			}
-
-			if err != nil {
-				t.Fatal(err)
-			}
-
-			if scanValue.String() != test.expectedValue {
-				t.Fatalf("expected: %v, got: %v", test.expectedValue, scanValue.String())
-			}
-		})
-	}
+	})
 }
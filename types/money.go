@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Money is an amount in a currency.
+type Money struct {
+	Amount   Decimal  `json:"amount"`
+	Currency Currency `json:"currency"`
+}
+
+func NewMoney(amount string, currency string) (Money, error) {
+	a, err := NewDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	c, err := NewCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{Amount: a, Currency: c}, nil
+}
+
+// String returns the amount and the upper-case currency code, eg. "12.50 EUR".
+func (m Money) String() string {
+	return strings.TrimSpace(m.Amount.String() + " " + m.Currency.Upper())
+}
+
+// Round rounds the amount to the minor units of the currency, eg. to cents for EUR.
+func (m Money) Round(mode RoundingMode) (Money, error) {
+	minorUnits, ok := m.Currency.MinorUnits()
+	if !ok {
+		return Money{}, fmt.Errorf("unknown minor units of currency: %s", m.Currency)
+	}
+
+	return Money{Amount: m.Amount.Round(int32(minorUnits), mode), Currency: m.Currency}, nil
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("currency mismatch: %s + %s", m, other)
+	}
+
+	return Money{Amount: m.Amount.Add(other.Amount), Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("currency mismatch: %s - %s", m, other)
+	}
+
+	return Money{Amount: m.Amount.Sub(other.Amount), Currency: m.Currency}, nil
+}
+
+// Cmp compares the amounts of m and other, which must be in the same currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if m.Currency != other.Currency {
+		return 0, fmt.Errorf("currency mismatch: %s <> %s", m, other)
+	}
+
+	return m.Amount.Cmp(other.Amount), nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
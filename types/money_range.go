@@ -0,0 +1,216 @@
+package types
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MoneyRange is a price band in a single currency, eg. "[10.00,50.00) EUR".
+// In PostgreSQL it is stored as a composite of a numrange and a currency, see Value, or store Amount in a numrange
+// column and Currency in a separate column, both implement sql.Scanner and driver.Valuer.
+type MoneyRange struct {
+	Amount   DecimalRange
+	Currency Currency
+}
+
+// NewMoneyRange parses a range in PostgreSQL range syntax followed by an optional currency code, eg.
+// "[10.00,50.00) EUR", "[10.00,50.00)" or "empty".
+func NewMoneyRange(s string) (MoneyRange, error) {
+	str := strings.TrimSpace(s)
+	amountStr, currencyStr := str, ""
+	if i := strings.LastIndexByte(str, ' '); i >= 0 && !strings.HasSuffix(str, ")") && !strings.HasSuffix(str, "]") {
+		amountStr, currencyStr = str[:i], str[i+1:]
+	}
+
+	amount, err := NewDecimalRange(amountStr)
+	if err != nil {
+		return MoneyRange{}, fmt.Errorf("invalid money range: %s: %v", s, err)
+	}
+
+	currency, err := NewCurrency(strings.ToLower(currencyStr))
+	if err != nil {
+		return MoneyRange{}, fmt.Errorf("invalid money range: %s: %v", s, err)
+	}
+
+	return MoneyRange{Amount: amount, Currency: currency}, nil
+}
+
+// String returns the range and the upper-case currency code, eg. "[10.00,50.00) EUR".
+func (r MoneyRange) String() string {
+	return strings.TrimSpace(r.Amount.String() + " " + r.Currency.Upper())
+}
+
+// Contains tells if m is in the range, money in another currency is never contained.
+func (r MoneyRange) Contains(m Money) bool {
+	return r.Currency == m.Currency && r.Amount.Contains(m.Amount)
+}
+
+// Overlaps tells if r and other have common values, ranges in different currencies never overlap.
+func (r MoneyRange) Overlaps(other MoneyRange) bool {
+	return r.Currency == other.Currency && r.Amount.Overlaps(other.Amount)
+}
+
+func (r MoneyRange) Intersect(other MoneyRange) (MoneyRange, error) {
+	if r.Currency != other.Currency {
+		return MoneyRange{}, fmt.Errorf("currency mismatch: %s * %s", r, other)
+	}
+
+	return MoneyRange{Amount: r.Amount.Intersect(other.Amount), Currency: r.Currency}, nil
+}
+
+func (r MoneyRange) Union(other MoneyRange) (MoneyRange, error) {
+	if r.Currency != other.Currency {
+		return MoneyRange{}, fmt.Errorf("currency mismatch: %s + %s", r, other)
+	}
+
+	amount, err := r.Amount.Union(other.Amount)
+	if err != nil {
+		return MoneyRange{}, err
+	}
+
+	return MoneyRange{Amount: amount, Currency: r.Currency}, nil
+}
+
+func (r MoneyRange) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *MoneyRange) UnmarshalText(b []byte) error {
+	moneyRange, err := NewMoneyRange(string(b))
+	if err != nil {
+		return err
+	}
+
+	*r = moneyRange
+
+	return nil
+}
+
+func (r MoneyRange) MarshalJSON() ([]byte, error) {
+	if r.Currency == "" && r.Amount.IsEmpty() {
+		return []byte("null"), nil
+	}
+
+	return []byte(strconv.Quote(r.String())), nil
+}
+
+func (r *MoneyRange) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+
+	return r.UnmarshalText([]byte(str))
+}
+
+func (r MoneyRange) MarshalBinary() ([]byte, error) {
+	return r.MarshalText()
+}
+
+func (r *MoneyRange) UnmarshalBinary(b []byte) error {
+	return r.UnmarshalText(b)
+}
+
+// Value stores the range as a PostgreSQL composite of a numrange and a currency, eg. ("[10.00,50.00)",eur) for
+//
+//	CREATE TYPE money_range AS (amount numrange, currency text);
+//
+// The zero range is stored as NULL.
+func (r MoneyRange) Value() (driver.Value, error) {
+	if r.Currency == "" && r.Amount.IsEmpty() {
+		return nil, nil
+	}
+
+	return "(" + quoteCompositeField(r.Amount.String()) + "," + quoteCompositeField(string(withOutputCase(r.Currency))) + ")", nil
+}
+
+func (r *MoneyRange) Scan(src interface{}) error {
+	var str string
+	switch src := src.(type) {
+	case nil:
+		*r = MoneyRange{}
+		return nil
+	case string:
+		str = src
+	case []byte:
+		str = string(src)
+	case sql.RawBytes:
+		str = string(src)
+	default:
+		return fmt.Errorf("cannot convert %T to MoneyRange", src)
+	}
+
+	fields, err := splitCompositeLiteral(str)
+	if err != nil || len(fields) != 2 {
+		return fmt.Errorf("invalid money range: %s", str)
+	}
+
+	amount := DecimalRange{}
+	if fields[0] != "" {
+		if amount, err = NewDecimalRange(fields[0]); err != nil {
+			return fmt.Errorf("invalid money range: %s: %v", str, err)
+		}
+	}
+
+	currency, err := NewCurrency(fields[1])
+	if err != nil {
+		return fmt.Errorf("invalid money range: %s: %v", str, err)
+	}
+
+	*r = MoneyRange{Amount: amount, Currency: currency}
+
+	return nil
+}
+
+// quoteCompositeField quotes a field of a PostgreSQL composite literal, the empty string is NULL.
+func quoteCompositeField(s string) string {
+	if s == "" || !strings.ContainsAny(s, "(),\" \t\n\r\v\f\\") {
+		return s
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `""`).Replace(s) + `"`
+}
+
+// splitCompositeLiteral returns the fields of a PostgreSQL composite literal, eg. ("[1,2)",eur), NULL fields are
+// empty.
+func splitCompositeLiteral(s string) ([]string, error) {
+	str := strings.TrimSpace(s)
+	if len(str) < 2 || str[0] != '(' || str[len(str)-1] != ')' {
+		return nil, fmt.Errorf("invalid composite: %s", s)
+	}
+
+	body := str[1 : len(str)-1]
+	fields := make([]string, 0, 2)
+	var field strings.Builder
+	quoted := false
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '\\' && i+1 < len(body):
+			i++
+			field.WriteByte(body[i])
+		case quoted && c == '"' && i+1 < len(body) && body[i+1] == '"':
+			i++
+			field.WriteByte('"')
+		case c == '"':
+			quoted = !quoted
+		case !quoted && c == ',':
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("invalid composite: %s", s)
+	}
+
+	return append(fields, field.String()), nil
+}
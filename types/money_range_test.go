@@ -0,0 +1,264 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMoneyRangeNew(t *testing.T) {
+	for index, test := range []struct {
+		text          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			text:          "[10.00,50.00) EUR",
+			expectedValue: "[10.00,50.00) EUR",
+		},
+		{
+			text:          "[100,) huf",
+			expectedValue: "[100,) HUF",
+		},
+		{
+			text:          "empty USD",
+			expectedValue: "empty USD",
+		},
+		{
+			text:          "[10.00, 50.00)",
+			expectedValue: "[10.00,50.00)",
+		},
+		{
+			text:          "empty",
+			expectedValue: "empty",
+		},
+		{
+			text:          "EUR",
+			expectedError: "invalid money range",
+		},
+		{
+			text:          "[10.00,50.00) EURO",
+			expectedError: "invalid currency",
+		},
+		{
+			text:          "[50,10) EUR",
+			expectedError: "greater than upper bound",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
+			result, err := NewMoneyRange(test.text)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestMoneyRangeOperations(t *testing.T) {
+	band := mustMoneyRange(t, "[10.00,50.00) EUR")
+
+	if !band.Contains(mustMoney(t, "10 eur")) {
+		t.Errorf("expected %s to contain 10 EUR", band)
+	}
+	if band.Contains(mustMoney(t, "50 eur")) {
+		t.Errorf("expected %s not to contain 50 EUR", band)
+	}
+	if band.Contains(mustMoney(t, "20 usd")) {
+		t.Errorf("expected %s not to contain 20 USD", band)
+	}
+	if band.Overlaps(mustMoneyRange(t, "[10,50) USD")) {
+		t.Errorf("expected ranges in different currencies not to overlap")
+	}
+
+	intersect, err := band.Intersect(mustMoneyRange(t, "[20,100] EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[20,50.00) EUR"; intersect.String() != expected {
+		t.Errorf("expected: %v, got: %v", expected, intersect)
+	}
+
+	union, err := band.Union(mustMoneyRange(t, "[50,100] EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "[10.00,100] EUR"; union.String() != expected {
+		t.Errorf("expected: %v, got: %v", expected, union)
+	}
+
+	if _, err := band.Union(mustMoneyRange(t, "[20,30) USD")); err == nil || !strings.Contains(err.Error(), "currency mismatch") {
+		t.Errorf("expected currency mismatch, got: %v", err)
+	}
+	if _, err := band.Intersect(mustMoneyRange(t, "[20,30) USD")); err == nil || !strings.Contains(err.Error(), "currency mismatch") {
+		t.Errorf("expected currency mismatch, got: %v", err)
+	}
+}
+
+func TestMoneyRangeJSON(t *testing.T) {
+	for index, test := range []struct {
+		json          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			json:          `"[10.00,50.00) EUR"`,
+			expectedValue: `"[10.00,50.00) EUR"`,
+		},
+		{
+			json:          `null`,
+			expectedValue: `null`,
+		},
+		{
+			json:          `"[10.00,50.00)"`,
+			expectedValue: `"[10.00,50.00)"`,
+		},
+		{
+			json:          `"empty"`,
+			expectedValue: `null`,
+		},
+		{
+			json:          `"[10.00,50.00) EURO"`,
+			expectedError: "invalid money range",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.json, test.expectedValue), func(t *testing.T) {
+			var r MoneyRange
+			err := json.Unmarshal([]byte(test.json), &r)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			b, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, string(b))
+			}
+		})
+	}
+}
+
+func TestMoneyRangeTextRoundTrip(t *testing.T) {
+	for index, test := range []MoneyRange{
+		{},
+		{Amount: mustDecimalRange(t, "[1,2)")},
+		{Amount: mustDecimalRange(t, "(,2]"), Currency: "huf"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test), func(t *testing.T) {
+			b, err := test.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result MoneyRange
+			if err := result.UnmarshalText(b); err != nil {
+				t.Fatal(err)
+			}
+			if result.String() != test.String() {
+				t.Fatalf("expected: %v, got: %v", test, result)
+			}
+		})
+	}
+}
+
+func TestMoneyRangeValue(t *testing.T) {
+	for index, test := range []struct {
+		value         MoneyRange
+		expectedValue driver.Value
+	}{
+		{value: mustMoneyRange(t, "[10.00,50.00) EUR"), expectedValue: `("[10.00,50.00)",eur)`},
+		{value: mustMoneyRange(t, "empty huf"), expectedValue: `(empty,huf)`},
+		{value: MoneyRange{Amount: mustDecimalRange(t, "(,5]")}, expectedValue: `("(,5]",)`},
+		{value: MoneyRange{}, expectedValue: nil},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.value, test.expectedValue), func(t *testing.T) {
+			result, err := test.value.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result != test.expectedValue {
+				t.Fatalf("expected: %#v, got: %#v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestMoneyRangeScan(t *testing.T) {
+	for index, test := range []struct {
+		src           interface{}
+		expectedValue string
+		expectedError string
+	}{
+		{src: `("[10.00,50.00)",EUR)`, expectedValue: "[10.00,50.00) EUR"},
+		{src: []byte(`(empty,huf)`), expectedValue: "empty HUF"},
+		{src: sql.RawBytes(`("(,5]",)`), expectedValue: "(,5]"},
+		{src: `(,"usd")`, expectedValue: "empty USD"},
+		{src: nil, expectedValue: "empty"},
+		{src: `("[10.00,50.00)",euro)`, expectedError: "invalid currency: euro"},
+		{src: `("[50,10)",eur)`, expectedError: "greater than upper bound"},
+		{src: `("[10.00,50.00)",eur`, expectedError: "invalid money range"},
+		{src: `("[10.00,50.00),eur)`, expectedError: "invalid money range"},
+		{src: `([10.00,50.00),eur)`, expectedError: "invalid money range"},
+		{src: 1, expectedError: "cannot convert int to MoneyRange"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			result := mustMoneyRange(t, "[1,2) gbp")
+			err := result.Scan(test.src)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestSplitCompositeLiteral(t *testing.T) {
+	for index, test := range []string{"", "a b", `a"b`, `a\b`, "(1,2]", "empty"} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test), func(t *testing.T) {
+			fields, err := splitCompositeLiteral("(" + quoteCompositeField(test) + ",x)")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if expected := []string{test, "x"}; !reflect.DeepEqual(fields, expected) {
+				t.Fatalf("expected: %q, got: %q", expected, fields)
+			}
+		})
+	}
+}
+
+func mustMoneyRange(t *testing.T, s string) MoneyRange {
+	t.Helper()
+
+	r, err := NewMoneyRange(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestMoneyArithmetic(t *testing.T) {
+	for index, test := range []struct {
+		a             string
+		op            string
+		b             string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			a:             "10.50 eur",
+			op:            "+",
+			b:             "0.05 eur",
+			expectedValue: "10.55 EUR",
+		},
+		{
+			a:             "10.50 eur",
+			op:            "-",
+			b:             "11 eur",
+			expectedValue: "-0.50 EUR",
+		},
+		{
+			a:             "10.50 eur",
+			op:            "+",
+			b:             "1 usd",
+			expectedError: "currency mismatch",
+		},
+		{
+			a:             "10.50 eur",
+			op:            "<>",
+			b:             "1 usd",
+			expectedError: "currency mismatch",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v %v %v -> %v", index+1, test.a, test.op, test.b, test.expectedValue), func(t *testing.T) {
+			a, b := mustMoney(t, test.a), mustMoney(t, test.b)
+
+			var result Money
+			var err error
+			switch test.op {
+			case "+":
+				result, err = a.Add(b)
+			case "-":
+				result, err = a.Sub(b)
+			case "<>":
+				_, err = a.Cmp(b)
+			}
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestMoneyRound(t *testing.T) {
+	m, err := NewMoney("0.125", "eur")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for mode, expected := range map[RoundingMode]string{
+		RoundHalfUp:   "0.13 EUR",
+		RoundHalfEven: "0.12 EUR",
+		RoundDown:     "0.12 EUR",
+	} {
+		result, err := m.Round(mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.String() != expected {
+			t.Errorf("mode %d: expected: %v, got: %v", mode, expected, result)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	m := mustMoney(t, "12.50 eur")
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"amount":"12.50","currency":"eur"}`; string(b) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, string(b))
+	}
+
+	var result Money
+	if err := json.Unmarshal(b, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.String() != m.String() {
+		t.Fatalf("expected: %v, got: %v", m, result)
+	}
+}
+
+func mustMoney(t *testing.T, s string) Money {
+	t.Helper()
+
+	parts := strings.Fields(s)
+	m, err := NewMoney(parts[0], parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m
+}
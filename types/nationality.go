@@ -0,0 +1,144 @@
+package types
+
+import (
+	"database/sql/driver"
+	_ "embed"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// demonyms.csv lists the demonym of each country by language, english is used as fallback
+//
+//go:embed data/demonyms.csv
+var demonymsCSV string
+
+var (
+	demonymsOnce sync.Once
+	demonyms     map[CountryCode]map[Language]string
+)
+
+// Nationality represented by the ISO 3166-1 Alpha-2 code of the country.
+// Codes not denoting a citizenship (T1 tor exit node, EU, territories without a demonym, eg. AQ) are not accepted.
+type Nationality string
+
+func NewNationality(code string) (Nationality, error) {
+	country, err := NewCountryCode(code)
+	if err != nil {
+		return "", &InvalidCodeError{Kind: "nationality", Input: code, Reason: ErrInvalidFormat}
+	}
+	if country == "" {
+		return "", nil
+	}
+
+	// Validate returns an *InvalidCodeError, keep its reason, eg. ErrWithdrawnCode
+	if err := country.Validate(); err != nil {
+		return "", &InvalidCodeError{Kind: "nationality", Input: code, Reason: errors.Unwrap(err)}
+	}
+
+	demonymsOnce.Do(loadDemonyms)
+	if _, ok := demonyms[country]; !ok {
+		return "", &InvalidCodeError{Kind: "nationality", Input: code, Reason: ErrUnknownCode}
+	}
+
+	return Nationality(country), nil
+}
+
+func (n Nationality) String() string {
+	return string(n)
+}
+
+func (n Nationality) CountryCode() CountryCode {
+	return CountryCode(n)
+}
+
+// Demonym returns the adjective of the nationality in the given language, eg. "German".
+// Falls back to english if the language is unknown, returns an empty string if the country is unknown.
+func (n Nationality) Demonym(lang Language) string {
+	demonymsOnce.Do(loadDemonyms)
+
+	byLang := demonyms[n.CountryCode()]
+	if demonym, ok := byLang[lang]; ok {
+		return demonym
+	}
+
+	return byLang["en"]
+}
+
+func (n Nationality) MarshalText() ([]byte, error) {
+	return marshalCodeText(withOutputCase(n))
+}
+
+func (n *Nationality) UnmarshalText(b []byte) error {
+	return unmarshalCodeText(n, b, NewNationality)
+}
+
+func (n Nationality) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(withOutputCase(n))
+}
+
+func (n *Nationality) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeJSON(n, b, NewNationality)
+}
+
+func (n Nationality) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(withOutputCase(n))
+}
+
+func (n *Nationality) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(n, node, NewNationality)
+}
+
+func (n Nationality) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(withXMLOutputCase(n), e, start)
+}
+
+func (n *Nationality) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(n, d, start, NewNationality)
+}
+
+func (n Nationality) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(withXMLOutputCase(n), name)
+}
+
+func (n *Nationality) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(n, attr, NewNationality)
+}
+
+func (n Nationality) MarshalBinary() ([]byte, error) {
+	return marshalCodeText(withOutputCase(n))
+}
+
+func (n *Nationality) UnmarshalBinary(b []byte) error {
+	return unmarshalCodeText(n, b, NewNationality)
+}
+
+func (n Nationality) Value() (driver.Value, error) {
+	return codeValue(withOutputCase(n))
+}
+
+func (n *Nationality) Scan(src interface{}) error {
+	return scanCode(n, src, NewNationality, "Nationality")
+}
+
+func loadDemonyms() {
+	demonyms = make(map[CountryCode]map[Language]string)
+
+	records, err := csv.NewReader(strings.NewReader(demonymsCSV)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("types: invalid embedded demonyms: %v", err))
+	}
+
+	for _, record := range records[1:] {
+		country, lang := CountryCode(record[0]), Language(record[1])
+		if demonyms[country] == nil {
+			demonyms[country] = make(map[Language]string)
+		}
+		demonyms[country][lang] = record[2]
+	}
+}
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/proemergotech/types/types/codetest"
+)
+
+func TestNationality(t *testing.T) {
+	codetest.Run(t, NewNationality, []codetest.Case{
+		{
+			Text:          "",
+			ExpectedValue: "",
+		},
+		{
+			Text:          "de",
+			ExpectedValue: "de",
+		},
+		{
+			Text:          "FR",
+			ExpectedValue: "fr",
+		},
+		{
+			Text:          "t1",
+			ExpectedError: "invalid nationality",
+		},
+		{
+			Text:          "EU",
+			ExpectedError: "invalid nationality",
+		},
+		{
+			Text:          "xx",
+			ExpectedError: "invalid nationality: xx: unknown code",
+		},
+		{
+			Text:          "YU",
+			ExpectedError: "invalid nationality: YU: withdrawn code",
+		},
+		{
+			Text:          "aq",
+			ExpectedError: "invalid nationality: aq: unknown code",
+		},
+		{
+			Text:          "12",
+			ExpectedError: "invalid nationality",
+		},
+		{
+			Text:          "hun",
+			ExpectedError: "invalid nationality",
+		},
+	})
+}
+
+func TestNationalityDemonym(t *testing.T) {
+	for index, test := range []struct {
+		nationality   Nationality
+		lang          Language
+		expectedValue string
+	}{
+		{
+			nationality:   "de",
+			lang:          "en",
+			expectedValue: "German",
+		},
+		{
+			nationality:   "fr",
+			lang:          "fr",
+			expectedValue: "Français",
+		},
+		{
+			nationality:   "hu",
+			lang:          "hu",
+			expectedValue: "Magyar",
+		},
+		{
+			nationality:   "ki",
+			lang:          "de",
+			expectedValue: "I-Kiribati",
+		},
+		{
+			nationality:   "ch",
+			lang:          "",
+			expectedValue: "Swiss",
+		},
+		{
+			nationality:   "",
+			lang:          "en",
+			expectedValue: "",
+		},
+		{
+			nationality:   "zz",
+			lang:          "en",
+			expectedValue: "",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v/%v -> %v", index+1, test.nationality, test.lang, test.expectedValue), func(t *testing.T) {
+			result := test.nationality.Demonym(test.lang)
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
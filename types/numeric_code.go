@@ -0,0 +1,61 @@
+package types
+
+import "strings"
+
+// Numeric forms of codes used by the compact encodings. A code only has a numeric form if the number maps back to
+// the same code, eg. the withdrawn mxp shares 484 with mxn, so it is encoded as a string.
+
+func countryCodeNumeric(c CountryCode) (uint64, bool) {
+	info, ok := lookupISO3166(c)
+	if !ok || info.numeric == 0 || !strings.EqualFold(string(countryByNumeric[info.numeric]), string(c)) {
+		return 0, false
+	}
+
+	return uint64(info.numeric), true
+}
+
+func countryCodeFromNumeric(n uint64) (CountryCode, bool) {
+	iso3166Once.Do(loadISO3166)
+
+	country, ok := countryByNumeric[int(n)]
+
+	return country, ok
+}
+
+func currencyNumeric(c Currency) (uint64, bool) {
+	info, ok := lookupISO4217(c)
+	if !ok || info.numeric == 0 || !strings.EqualFold(string(currencyByNumeric[info.numeric]), string(c)) {
+		return 0, false
+	}
+
+	return uint64(info.numeric), true
+}
+
+func currencyFromNumeric(n uint64) (Currency, bool) {
+	iso4217Once.Do(loadISO4217)
+
+	currency, ok := currencyByNumeric[int(n)]
+
+	return currency, ok
+}
+
+// languageNumeric returns the index of the language among the two-letter codes, aa is 0 and zz is 675.
+func languageNumeric(l Language) (uint64, bool) {
+	if len(l) != 2 || !isLowerLetter(l[0]) || !isLowerLetter(l[1]) {
+		return 0, false
+	}
+
+	return uint64(l[0]-'a')*26 + uint64(l[1]-'a'), true
+}
+
+func languageFromNumeric(n uint64) (Language, bool) {
+	if n >= 26*26 {
+		return "", false
+	}
+
+	return Language([]byte{byte('a' + n/26), byte('a' + n%26)}), true
+}
+
+func isLowerLetter(b byte) bool {
+	return b >= 'a' && b <= 'z'
+}
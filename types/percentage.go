@@ -0,0 +1,167 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Percentage is an exact ratio, eg. a fee or a tax rate. The zero value is 0%.
+type Percentage struct {
+	ratio Decimal
+}
+
+// NewPercentage parses a percentage ("2.5%"), basis points ("250bps", "250 bp") or a plain ratio ("0.025").
+func NewPercentage(s string) (Percentage, error) {
+	str := strings.TrimSpace(s)
+	shift := int32(0)
+
+	switch lower := strings.ToLower(str); {
+	case strings.HasSuffix(lower, "%"):
+		str, shift = str[:len(str)-len("%")], 2
+	case strings.HasSuffix(lower, "bps"):
+		str, shift = str[:len(str)-len("bps")], 4
+	case strings.HasSuffix(lower, "bp"):
+		str, shift = str[:len(str)-len("bp")], 4
+	}
+
+	value, err := NewDecimal(strings.TrimSpace(str))
+	if err != nil {
+		return Percentage{}, fmt.Errorf("invalid percentage: %s", s)
+	}
+
+	return Percentage{ratio: shiftDecimal(value, shift)}, nil
+}
+
+// NewPercentageFromRatio returns the percentage of ratio, eg. 0.025 is 2.5%.
+func NewPercentageFromRatio(ratio Decimal) Percentage {
+	return Percentage{ratio: ratio}
+}
+
+// NewPercentageFromBasisPoints returns the percentage of bps basis points, eg. 250 is 2.5%.
+func NewPercentageFromBasisPoints(bps int64) Percentage {
+	return Percentage{ratio: NewDecimalFromInt(bps, 4)}
+}
+
+// String returns the percentage with a % sign, eg. "2.5%".
+func (p Percentage) String() string {
+	return p.Percent().String() + "%"
+}
+
+// Ratio returns the percentage as a ratio, eg. 0.025 for 2.5%.
+func (p Percentage) Ratio() Decimal {
+	return p.ratio
+}
+
+// Percent returns the percentage without the % sign, eg. 2.5 for 2.5%.
+func (p Percentage) Percent() Decimal {
+	return shiftDecimal(p.ratio, -2)
+}
+
+// BasisPoints returns the percentage in basis points, eg. 250 for 2.5%.
+func (p Percentage) BasisPoints() Decimal {
+	return shiftDecimal(p.ratio, -4)
+}
+
+func (p Percentage) IsZero() bool {
+	return p.ratio.IsZero()
+}
+
+func (p Percentage) Cmp(other Percentage) int {
+	return p.ratio.Cmp(other.ratio)
+}
+
+// Of returns the exact percentage of amount, without rounding.
+func (p Percentage) Of(amount Decimal) Decimal {
+	return amount.Mul(p.ratio)
+}
+
+// Apply returns the percentage of m, rounded to the minor units of its currency with mode.
+func (p Percentage) Apply(m Money, mode RoundingMode) (Money, error) {
+	return Money{Amount: p.Of(m.Amount), Currency: m.Currency}.Round(mode)
+}
+
+func (p Percentage) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+func (p *Percentage) UnmarshalText(b []byte) error {
+	percentage, err := NewPercentage(string(b))
+	if err != nil {
+		return err
+	}
+
+	*p = percentage
+
+	return nil
+}
+
+func (p Percentage) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(p.String())), nil
+}
+
+// UnmarshalJSON accepts the string forms of NewPercentage, and numbers as ratios.
+func (p *Percentage) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+
+	str := string(b)
+	if strings.HasPrefix(str, `"`) {
+		var err error
+		if str, err = strconv.Unquote(str); err != nil {
+			return err
+		}
+	}
+
+	percentage, err := NewPercentage(str)
+	if err != nil {
+		return err
+	}
+
+	*p = percentage
+
+	return nil
+}
+
+func (p Percentage) MarshalBinary() ([]byte, error) {
+	return p.MarshalText()
+}
+
+func (p *Percentage) UnmarshalBinary(b []byte) error {
+	return p.UnmarshalText(b)
+}
+
+// Value stores the ratio, to fit numeric columns.
+func (p Percentage) Value() (driver.Value, error) {
+	return p.ratio.Value()
+}
+
+func (p *Percentage) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case string:
+		return p.UnmarshalText([]byte(src))
+	case []byte:
+		return p.UnmarshalText(src)
+	}
+
+	var ratio Decimal
+	if err := ratio.Scan(src); err != nil {
+		return fmt.Errorf("cannot convert %T to Percentage", src)
+	}
+
+	*p = Percentage{ratio: ratio}
+
+	return nil
+}
+
+// shiftDecimal moves the decimal point of d to the left by n digits, or to the right if n is negative.
+func shiftDecimal(d Decimal, n int32) Decimal {
+	if scale := d.scale + n; scale >= 0 {
+		return Decimal{value: d.unscaled(), scale: scale}
+	}
+
+	return NewDecimalFromBigInt(d.unscaled(), d.scale+n)
+}
@@ -0,0 +1,290 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPercentageNew(t *testing.T) {
+	for index, test := range []struct {
+		text          string
+		expectedRatio string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			text:          "2.5%",
+			expectedRatio: "0.025",
+			expectedValue: "2.5%",
+		},
+		{
+			text:          " 27 % ",
+			expectedRatio: "0.27",
+			expectedValue: "27%",
+		},
+		{
+			text:          "250bps",
+			expectedRatio: "0.0250",
+			expectedValue: "2.50%",
+		},
+		{
+			text:          "1 BP",
+			expectedRatio: "0.0001",
+			expectedValue: "0.01%",
+		},
+		{
+			text:          "0.025",
+			expectedRatio: "0.025",
+			expectedValue: "2.5%",
+		},
+		{
+			text:          "2",
+			expectedRatio: "2",
+			expectedValue: "200%",
+		},
+		{
+			text:          "-0.5%",
+			expectedRatio: "-0.005",
+			expectedValue: "-0.5%",
+		},
+		{
+			text:          "",
+			expectedError: "invalid percentage",
+		},
+		{
+			text:          "%",
+			expectedError: "invalid percentage",
+		},
+		{
+			text:          "2.5%%",
+			expectedError: "invalid percentage",
+		},
+		{
+			text:          "1e2%",
+			expectedError: "invalid percentage",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
+			result, err := NewPercentage(test.text)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.Ratio().String() != test.expectedRatio {
+				t.Fatalf("expected ratio: %v, got: %v", test.expectedRatio, result.Ratio())
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestPercentageBasisPoints(t *testing.T) {
+	p := NewPercentageFromBasisPoints(250)
+	if p.String() != "2.50%" {
+		t.Fatalf("expected: 2.50%%, got: %v", p)
+	}
+	if !p.BasisPoints().Equal(MustDecimal("250")) {
+		t.Fatalf("expected: 250, got: %v", p.BasisPoints())
+	}
+	if p.Cmp(NewPercentageFromRatio(MustDecimal("0.025"))) != 0 {
+		t.Fatalf("expected 250bps to equal 0.025")
+	}
+}
+
+func TestPercentageApply(t *testing.T) {
+	for index, test := range []struct {
+		percentage    string
+		amount        string
+		currency      string
+		mode          RoundingMode
+		expectedValue string
+		expectedError string
+	}{
+		{
+			percentage:    "2.5%",
+			amount:        "10.10",
+			currency:      "eur",
+			mode:          RoundHalfUp,
+			expectedValue: "0.25 EUR",
+		},
+		{
+			percentage:    "2.5%",
+			amount:        "10.10",
+			currency:      "eur",
+			mode:          RoundUp,
+			expectedValue: "0.26 EUR",
+		},
+		{
+			percentage:    "2.5%",
+			amount:        "10.10",
+			currency:      "eur",
+			mode:          RoundHalfEven,
+			expectedValue: "0.25 EUR",
+		},
+		{
+			percentage:    "27%",
+			amount:        "1999",
+			currency:      "huf",
+			mode:          RoundHalfUp,
+			expectedValue: "539.73 HUF",
+		},
+		{
+			percentage:    "10%",
+			amount:        "1255",
+			currency:      "jpy",
+			mode:          RoundHalfUp,
+			expectedValue: "126 JPY",
+		},
+		{
+			percentage:    "1bp",
+			amount:        "100",
+			currency:      "kwd",
+			mode:          RoundHalfUp,
+			expectedValue: "0.010 KWD",
+		},
+		{
+			percentage:    "1%",
+			amount:        "100",
+			currency:      "xau",
+			mode:          RoundHalfUp,
+			expectedError: "unknown minor units",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v of %v %v -> %v", index+1, test.percentage, test.amount, test.currency, test.expectedValue), func(t *testing.T) {
+			p, err := NewPercentage(test.percentage)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m, err := NewMoney(test.amount, test.currency)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := p.Apply(m, test.mode)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestPercentageJSON(t *testing.T) {
+	for index, test := range []struct {
+		json          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			json:          `"2.5%"`,
+			expectedValue: `"2.5%"`,
+		},
+		{
+			json:          `"250bps"`,
+			expectedValue: `"2.50%"`,
+		},
+		{
+			json:          `0.025`,
+			expectedValue: `"2.5%"`,
+		},
+		{
+			json:          `null`,
+			expectedValue: `"0%"`,
+		},
+		{
+			json:          `"foo"`,
+			expectedError: "invalid percentage",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.json, test.expectedValue), func(t *testing.T) {
+			var p Percentage
+			err := json.Unmarshal([]byte(test.json), &p)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			b, err := json.Marshal(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, string(b))
+			}
+		})
+	}
+}
+
+func TestPercentageSql(t *testing.T) {
+	for index, test := range []struct {
+		src           interface{}
+		expectedValue string
+		expectedError string
+	}{
+		{
+			src:           nil,
+			expectedValue: "0",
+		},
+		{
+			src:           "0.025",
+			expectedValue: "0.025",
+		},
+		{
+			src:           []byte("2.5%"),
+			expectedValue: "0.025",
+		},
+		{
+			src:           0.25,
+			expectedValue: "0.25",
+		},
+		{
+			src:           "foo",
+			expectedError: "invalid percentage",
+		},
+		{
+			src:           true,
+			expectedError: "cannot convert bool to Percentage",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			var scanValue Percentage
+			err := scanValue.Scan(test.src)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			driverValue, err := scanValue.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if driverValue != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, driverValue)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/xml"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+var subdivisionValidator = regexp.MustCompile(`^[A-Za-z]{2}-[A-Za-z0-9]{1,3}$`)
+
+// ISO 3166-2 country subdivision code, eg. "es-cn" for the Canary Islands
+type Subdivision string
+
+type subdivisionSpec struct{}
+
+func (subdivisionSpec) CodeName() string {
+	return "subdivision"
+}
+
+func (subdivisionSpec) ValidCode(code string) bool {
+	return subdivisionValidator.MatchString(code)
+}
+
+func NewSubdivision(code string) (Subdivision, error) {
+	return parseCode[Subdivision](subdivisionSpec{}, code)
+}
+
+func (s Subdivision) String() string {
+	return string(s)
+}
+
+// Country returns the country of the subdivision, eg. "es" for "es-cn".
+func (s Subdivision) Country() CountryCode {
+	if len(s) < 2 {
+		return ""
+	}
+
+	return CountryCode(s[:2])
+}
+
+func (s Subdivision) MarshalText() ([]byte, error) {
+	return marshalCodeText(withOutputCase(s))
+}
+
+func (s *Subdivision) UnmarshalText(b []byte) error {
+	return unmarshalCodeText(s, b, NewSubdivision)
+}
+
+func (s Subdivision) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(withOutputCase(s))
+}
+
+func (s *Subdivision) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeJSON(s, b, NewSubdivision)
+}
+
+func (s Subdivision) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(withOutputCase(s))
+}
+
+func (s *Subdivision) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(s, node, NewSubdivision)
+}
+
+func (s Subdivision) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(withXMLOutputCase(s), e, start)
+}
+
+func (s *Subdivision) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(s, d, start, NewSubdivision)
+}
+
+func (s Subdivision) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(withXMLOutputCase(s), name)
+}
+
+func (s *Subdivision) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(s, attr, NewSubdivision)
+}
+
+func (s Subdivision) MarshalBinary() ([]byte, error) {
+	return marshalCodeText(withOutputCase(s))
+}
+
+func (s *Subdivision) UnmarshalBinary(b []byte) error {
+	return unmarshalCodeText(s, b, NewSubdivision)
+}
+
+func (s Subdivision) Value() (driver.Value, error) {
+	return codeValue(withOutputCase(s))
+}
+
+func (s *Subdivision) Scan(src interface{}) error {
+	return scanCode(s, src, NewSubdivision, "Subdivision")
+}
@@ -0,0 +1,93 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types/codetest"
+)
+
+func TestSubdivisionNew(t *testing.T) {
+	for index, test := range []struct {
+		text            string
+		expectedValue   Subdivision
+		expectedCountry CountryCode
+		expectedError   string
+	}{
+		{
+			text:          "",
+			expectedValue: "",
+		},
+		{
+			text:            "ES-CN",
+			expectedValue:   "es-cn",
+			expectedCountry: "es",
+		},
+		{
+			text:            "pt-30",
+			expectedValue:   "pt-30",
+			expectedCountry: "pt",
+		},
+		{
+			text:            "gb-eng",
+			expectedValue:   "gb-eng",
+			expectedCountry: "gb",
+		},
+		{
+			text:          "es",
+			expectedError: "invalid subdivision",
+		},
+		{
+			text:          "es-",
+			expectedError: "invalid subdivision",
+		},
+		{
+			text:          "es-cnxx",
+			expectedError: "invalid subdivision",
+		},
+		{
+			text:          "e1-cn",
+			expectedError: "invalid subdivision",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.text, test.expectedValue), func(t *testing.T) {
+			result, err := NewSubdivision(test.text)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+			if result.Country() != test.expectedCountry {
+				t.Fatalf("expected country: %v, got: %v", test.expectedCountry, result.Country())
+			}
+		})
+	}
+}
+
+func TestSubdivision(t *testing.T) {
+	codetest.Run(t, NewSubdivision, []codetest.Case{
+		{
+			Text:          "",
+			ExpectedValue: "",
+		},
+		{
+			Text:          "ES-CN",
+			ExpectedValue: "es-cn",
+		},
+		{
+			Text:          "pt-30",
+			ExpectedValue: "pt-30",
+		},
+		{
+			Text:          "es",
+			ExpectedError: "invalid subdivision",
+		},
+	})
+}
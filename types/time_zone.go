@@ -0,0 +1,157 @@
+package types
+
+import (
+	"bufio"
+	"database/sql/driver"
+	_ "embed"
+	"encoding/xml"
+	"strings"
+	"sync"
+	"time"
+	// the tz database is embedded, so time zones are validated the same way on hosts without tzdata, eg. distroless
+	_ "time/tzdata"
+
+	"gopkg.in/yaml.v3"
+)
+
+// zone.tab is the one-country-per-row variant of the tzdb zone1970.tab
+//
+//go:embed data/zone.tab
+var zoneTab string
+
+var (
+	zoneTableOnce      sync.Once
+	timeZonesByCountry map[CountryCode][]TimeZone
+	countryByTimeZone  map[TimeZone]CountryCode
+)
+
+// IANA time zone database name, eg. Europe/Budapest
+type TimeZone string
+
+func NewTimeZone(name string) (TimeZone, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	// "Local" is accepted by time.LoadLocation, but depends on the host
+	if name == "Local" {
+		return "", &InvalidCodeError{Kind: "time zone", Input: name, Reason: ErrUnknownCode}
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return "", &InvalidCodeError{Kind: "time zone", Input: name, Reason: ErrUnknownCode}
+	}
+
+	return TimeZone(name), nil
+}
+
+func (t TimeZone) String() string {
+	return string(t)
+}
+
+// Location returns UTC for the empty time zone.
+func (t TimeZone) Location() (*time.Location, error) {
+	return time.LoadLocation(t.String())
+}
+
+// Country returns the country the time zone belongs to, or an empty CountryCode
+// if it is not bound to a single country (eg. UTC, Etc/GMT+1).
+func (t TimeZone) Country() CountryCode {
+	loadZoneTable()
+
+	return countryByTimeZone[t]
+}
+
+func (t TimeZone) MarshalText() ([]byte, error) {
+	return marshalCodeText(t)
+}
+
+func (t *TimeZone) UnmarshalText(b []byte) error {
+	return unmarshalCodeText(t, b, NewTimeZone)
+}
+
+func (t TimeZone) MarshalJSON() ([]byte, error) {
+	return marshalCodeJSON(t)
+}
+
+func (t *TimeZone) UnmarshalJSON(b []byte) error {
+	return unmarshalCodeJSON(t, b, NewTimeZone)
+}
+
+func (t TimeZone) MarshalYAML() (interface{}, error) {
+	return marshalCodeYAML(t)
+}
+
+func (t *TimeZone) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalCodeYAML(t, node, NewTimeZone)
+}
+
+func (t TimeZone) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalCodeXML(t, e, start)
+}
+
+func (t *TimeZone) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return unmarshalCodeXML(t, d, start, NewTimeZone)
+}
+
+func (t TimeZone) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	return marshalCodeXMLAttr(t, name)
+}
+
+func (t *TimeZone) UnmarshalXMLAttr(attr xml.Attr) error {
+	return unmarshalCodeXMLAttr(t, attr, NewTimeZone)
+}
+
+func (t TimeZone) MarshalBinary() ([]byte, error) {
+	return marshalCodeText(t)
+}
+
+func (t *TimeZone) UnmarshalBinary(b []byte) error {
+	return unmarshalCodeText(t, b, NewTimeZone)
+}
+
+func (t TimeZone) Value() (driver.Value, error) {
+	return codeValue(t)
+}
+
+func (t *TimeZone) Scan(src interface{}) error {
+	return scanCode(t, src, NewTimeZone, "TimeZone")
+}
+
+// TimeZones returns the IANA time zones used in the country, in tzdb order.
+func (c CountryCode) TimeZones() []TimeZone {
+	loadZoneTable()
+
+	zones := timeZonesByCountry[c]
+	if len(zones) == 0 {
+		return nil
+	}
+
+	return append([]TimeZone(nil), zones...)
+}
+
+func loadZoneTable() {
+	zoneTableOnce.Do(func() {
+		timeZonesByCountry = make(map[CountryCode][]TimeZone)
+		countryByTimeZone = make(map[TimeZone]CountryCode)
+
+		scanner := bufio.NewScanner(strings.NewReader(zoneTab))
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			fields := strings.Split(line, "\t")
+			if len(fields) < 3 {
+				continue
+			}
+
+			code := CountryCode(strings.ToLower(fields[0]))
+			tz := TimeZone(fields[2])
+
+			timeZonesByCountry[code] = append(timeZonesByCountry[code], tz)
+			countryByTimeZone[tz] = code
+		}
+	})
+}
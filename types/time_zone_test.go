@@ -0,0 +1,109 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/proemergotech/types/types/codetest"
+)
+
+func TestTimeZone(t *testing.T) {
+	codetest.Run(t, NewTimeZone, []codetest.Case{
+		{
+			Text:          "",
+			ExpectedValue: "",
+		},
+		{
+			Text:          "Europe/Budapest",
+			ExpectedValue: "Europe/Budapest",
+		},
+		{
+			Text:          "America/Argentina/Buenos_Aires",
+			ExpectedValue: "America/Argentina/Buenos_Aires",
+		},
+		{
+			Text:          "UTC",
+			ExpectedValue: "UTC",
+		},
+		{
+			Text:          "Local",
+			ExpectedError: "invalid time zone",
+		},
+		{
+			Text:          "Europe/Foo",
+			ExpectedError: "invalid time zone",
+		},
+		{
+			Text:          "../etc/passwd",
+			ExpectedError: "invalid time zone",
+		},
+	})
+}
+
+func TestTimeZoneCountry(t *testing.T) {
+	for index, test := range []struct {
+		tz            TimeZone
+		expectedValue CountryCode
+	}{
+		{
+			tz:            "",
+			expectedValue: "",
+		},
+		{
+			tz:            "Europe/Budapest",
+			expectedValue: "hu",
+		},
+		{
+			tz:            "America/Indiana/Knox",
+			expectedValue: "us",
+		},
+		{
+			tz:            "UTC",
+			expectedValue: "",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.tz, test.expectedValue), func(t *testing.T) {
+			result := test.tz.Country()
+			if result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestCountryCodeTimeZones(t *testing.T) {
+	for index, test := range []struct {
+		code          CountryCode
+		expectedValue []TimeZone
+	}{
+		{
+			code:          "",
+			expectedValue: nil,
+		},
+		{
+			code:          "hu",
+			expectedValue: []TimeZone{"Europe/Budapest"},
+		},
+		{
+			code:          "de",
+			expectedValue: []TimeZone{"Europe/Berlin", "Europe/Busingen"},
+		},
+		{
+			code:          "t1",
+			expectedValue: nil,
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.code, test.expectedValue), func(t *testing.T) {
+			result := test.code.TimeZones()
+			if !reflect.DeepEqual(result, test.expectedValue) {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+			for _, tz := range result {
+				if _, err := tz.Location(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
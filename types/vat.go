@@ -0,0 +1,247 @@
+package types
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"sync"
+)
+
+// vat_rates.csv holds the standard and reduced VAT rates of European countries, and of subdivisions with their own rates, as of 2025
+//
+//go:embed data/vat_rates.csv
+var vatRatesCSV string
+
+var (
+	defaultVATTableOnce sync.Once
+	defaultVATTable     *VATTable
+)
+
+// TaxRounding tells whether taxes are rounded per line or once for the total.
+type TaxRounding int
+
+const (
+	// TaxRoundPerLine rounds the tax of each line, the total is the sum of the rounded lines.
+	TaxRoundPerLine TaxRounding = iota
+	// TaxRoundPerTotal sums the lines and rounds the tax of the sum once.
+	TaxRoundPerTotal
+)
+
+// VATRates are the VAT rates of a country or subdivision.
+type VATRates struct {
+	Standard Percentage
+	Reduced  []Percentage
+}
+
+// VATTable holds VAT rates by CountryCode and Subdivision.
+type VATTable struct {
+	rates map[string]VATRates
+}
+
+// TaxFromNet returns the tax on a net amount, rounded to the minor units of its currency.
+func TaxFromNet(net Money, rate Percentage, mode RoundingMode) (Money, error) {
+	return rate.Apply(net, mode)
+}
+
+// TaxFromGross returns the tax included in a gross amount, rounded to the minor units of its currency.
+func TaxFromGross(gross Money, rate Percentage, mode RoundingMode) (Money, error) {
+	minorUnits, ok := gross.Currency.MinorUnits()
+	if !ok {
+		return Money{}, fmt.Errorf("unknown minor units of currency: %s", gross.Currency)
+	}
+
+	divisor := NewDecimalFromInt(1, 0).Add(rate.Ratio())
+	if divisor.Sign() <= 0 {
+		return Money{}, fmt.Errorf("invalid tax rate: %s", rate)
+	}
+
+	// tax = gross * rate / (1 + rate)
+	tax := new(big.Rat).Mul(gross.Amount.Rat(), rate.Ratio().Rat())
+	tax.Quo(tax, divisor.Rat())
+
+	return Money{Amount: NewDecimalFromRat(tax, int32(minorUnits), mode), Currency: gross.Currency}, nil
+}
+
+// GrossFromNet returns the net amount plus tax.
+func GrossFromNet(net Money, rate Percentage, mode RoundingMode) (Money, error) {
+	tax, err := TaxFromNet(net, rate, mode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	gross, err := net.Add(tax)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return gross.Round(mode)
+}
+
+// NetFromGross returns the gross amount minus the included tax.
+func NetFromGross(gross Money, rate Percentage, mode RoundingMode) (Money, error) {
+	tax, err := TaxFromGross(gross, rate, mode)
+	if err != nil {
+		return Money{}, err
+	}
+
+	net, err := gross.Sub(tax)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return net.Round(mode)
+}
+
+// GrossFromNetLines returns the gross total of net lines, which must be in the same currency.
+func GrossFromNetLines(lines []Money, rate Percentage, rounding TaxRounding, mode RoundingMode) (Money, error) {
+	return sumTaxLines(lines, rounding, func(m Money) (Money, error) {
+		return GrossFromNet(m, rate, mode)
+	})
+}
+
+// NetFromGrossLines returns the net total of gross lines, which must be in the same currency.
+func NetFromGrossLines(lines []Money, rate Percentage, rounding TaxRounding, mode RoundingMode) (Money, error) {
+	return sumTaxLines(lines, rounding, func(m Money) (Money, error) {
+		return NetFromGross(m, rate, mode)
+	})
+}
+
+func sumTaxLines(lines []Money, rounding TaxRounding, convert func(Money) (Money, error)) (Money, error) {
+	if len(lines) == 0 {
+		return Money{}, nil
+	}
+
+	if rounding == TaxRoundPerTotal {
+		total := lines[0]
+		for _, line := range lines[1:] {
+			var err error
+			if total, err = total.Add(line); err != nil {
+				return Money{}, err
+			}
+		}
+
+		return convert(total)
+	}
+
+	var total Money
+	for i, line := range lines {
+		converted, err := convert(line)
+		if err != nil {
+			return Money{}, err
+		}
+
+		if i == 0 {
+			total = converted
+		} else if total, err = total.Add(converted); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return total, nil
+}
+
+// NewVATTable reads VAT rates from csv with a "region,standard,reduced" header, eg. "fr,20%,2.1% 5.5% 10%".
+// Regions are country codes or subdivisions, reduced rates are separated by spaces.
+func NewVATTable(r io.Reader) (*VATTable, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 3
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid vat rates: %v", err)
+	}
+	if strings.Join(header, ",") != "region,standard,reduced" {
+		return nil, fmt.Errorf("invalid vat rates: unexpected header: %s", strings.Join(header, ","))
+	}
+
+	t := &VATTable{rates: make(map[string]VATRates)}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid vat rates: %v", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		region, err := parseVATRegion(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid vat rates: line %d: %v", line, err)
+		}
+		if _, ok := t.rates[region]; ok {
+			return nil, fmt.Errorf("invalid vat rates: line %d: duplicate region: %s", line, region)
+		}
+
+		var rates VATRates
+		if rates.Standard, err = NewPercentage(record[1]); err != nil {
+			return nil, fmt.Errorf("invalid vat rates: line %d: %v", line, err)
+		}
+		for _, field := range strings.Fields(record[2]) {
+			rate, err := NewPercentage(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid vat rates: line %d: %v", line, err)
+			}
+			rates.Reduced = append(rates.Reduced, rate)
+		}
+
+		t.rates[region] = rates
+	}
+
+	return t, nil
+}
+
+// DefaultVATTable returns the embedded VAT rates. Rates change, use NewVATTable for up to date data.
+func DefaultVATTable() *VATTable {
+	defaultVATTableOnce.Do(func() {
+		var err error
+		if defaultVATTable, err = NewVATTable(strings.NewReader(vatRatesCSV)); err != nil {
+			panic(fmt.Sprintf("types: invalid embedded vat rates: %v", err))
+		}
+	})
+
+	return defaultVATTable
+}
+
+// Rates returns the VAT rates of the country.
+func (t *VATTable) Rates(country CountryCode) (VATRates, bool) {
+	return t.lookup(strings.ToLower(country.String()))
+}
+
+// SubdivisionRates returns the VAT rates of the subdivision, or of its country if it has no own rates.
+func (t *VATTable) SubdivisionRates(subdivision Subdivision) (VATRates, bool) {
+	if rates, ok := t.lookup(strings.ToLower(subdivision.String())); ok {
+		return rates, true
+	}
+
+	return t.Rates(subdivision.Country())
+}
+
+func (t *VATTable) lookup(region string) (VATRates, bool) {
+	rates, ok := t.rates[region]
+	if !ok {
+		return VATRates{}, false
+	}
+
+	rates.Reduced = append([]Percentage(nil), rates.Reduced...)
+
+	return rates, true
+}
+
+func parseVATRegion(s string) (string, error) {
+	if len(s) == 2 {
+		country, err := NewCountryCode(s)
+		return country.String(), err
+	}
+
+	subdivision, err := NewSubdivision(s)
+	if err == nil && subdivision == "" {
+		err = fmt.Errorf("invalid subdivision: %s", s)
+	}
+
+	return subdivision.String(), err
+}
@@ -0,0 +1,289 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTax(t *testing.T) {
+	for index, test := range []struct {
+		amount        string
+		currency      string
+		rate          string
+		expectedGross string
+		expectedNet   string
+		expectedError string
+	}{
+		{
+			amount:        "100.00",
+			currency:      "eur",
+			rate:          "27%",
+			expectedGross: "127.00 EUR",
+			expectedNet:   "78.74 EUR",
+		},
+		{
+			amount:        "10",
+			currency:      "eur",
+			rate:          "19%",
+			expectedGross: "11.90 EUR",
+			expectedNet:   "8.40 EUR",
+		},
+		{
+			amount:        "1000",
+			currency:      "jpy",
+			rate:          "8%",
+			expectedGross: "1080 JPY",
+			expectedNet:   "926 JPY",
+		},
+		{
+			amount:        "10",
+			currency:      "eur",
+			rate:          "0%",
+			expectedGross: "10.00 EUR",
+			expectedNet:   "10.00 EUR",
+		},
+		{
+			amount:        "10",
+			currency:      "xau",
+			rate:          "10%",
+			expectedError: "unknown minor units",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v %v at %v", index+1, test.amount, test.currency, test.rate), func(t *testing.T) {
+			m, err := NewMoney(test.amount, test.currency)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rate, err := NewPercentage(test.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gross, err := GrossFromNet(m, rate, RoundHalfUp)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if gross.String() != test.expectedGross {
+				t.Errorf("gross: expected: %v, got: %v", test.expectedGross, gross)
+			}
+
+			net, err := NetFromGross(m, rate, RoundHalfUp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if net.String() != test.expectedNet {
+				t.Errorf("net: expected: %v, got: %v", test.expectedNet, net)
+			}
+		})
+	}
+}
+
+func TestTaxInvalidRate(t *testing.T) {
+	_, err := NetFromGross(mustMoney(t, "10 eur"), NewPercentageFromBasisPoints(-10000), RoundHalfUp)
+	if err == nil || !strings.Contains(err.Error(), "invalid tax rate") {
+		t.Fatalf("expected invalid tax rate, got: %v", err)
+	}
+}
+
+func TestTaxLines(t *testing.T) {
+	for index, test := range []struct {
+		lines         []string
+		rate          string
+		gross         bool
+		rounding      TaxRounding
+		expectedValue string
+		expectedError string
+	}{
+		{
+			lines:         []string{"0.03 eur", "0.03 eur", "0.03 eur"},
+			rate:          "19%",
+			gross:         true,
+			rounding:      TaxRoundPerLine,
+			expectedValue: "0.12 EUR",
+		},
+		{
+			lines:         []string{"0.03 eur", "0.03 eur", "0.03 eur"},
+			rate:          "19%",
+			gross:         true,
+			rounding:      TaxRoundPerTotal,
+			expectedValue: "0.11 EUR",
+		},
+		{
+			lines:         []string{"0.10 eur", "0.10 eur", "0.10 eur"},
+			rate:          "21%",
+			rounding:      TaxRoundPerLine,
+			expectedValue: "0.24 EUR",
+		},
+		{
+			lines:         []string{"0.10 eur", "0.10 eur", "0.10 eur"},
+			rate:          "21%",
+			rounding:      TaxRoundPerTotal,
+			expectedValue: "0.25 EUR",
+		},
+		{
+			lines:         []string{"1 eur", "1 usd"},
+			rate:          "21%",
+			rounding:      TaxRoundPerTotal,
+			expectedError: "currency mismatch",
+		},
+		{
+			lines:         []string{"1 eur", "1 usd"},
+			rate:          "21%",
+			rounding:      TaxRoundPerLine,
+			expectedError: "currency mismatch",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v at %v -> %v", index+1, test.lines, test.rate, test.expectedValue), func(t *testing.T) {
+			var lines []Money
+			for _, line := range test.lines {
+				lines = append(lines, mustMoney(t, line))
+			}
+			rate, err := NewPercentage(test.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var result Money
+			if test.gross {
+				result, err = GrossFromNetLines(lines, rate, test.rounding, RoundHalfUp)
+			} else {
+				result, err = NetFromGrossLines(lines, rate, test.rounding, RoundHalfUp)
+			}
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+			if result.String() != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestDefaultVATTable(t *testing.T) {
+	for index, test := range []struct {
+		region           string
+		expectedStandard string
+		expectedReduced  string
+		expectedOk       bool
+	}{
+		{
+			region:           "hu",
+			expectedStandard: "27%",
+			expectedReduced:  "[5% 18%]",
+			expectedOk:       true,
+		},
+		{
+			region:           "dk",
+			expectedStandard: "25%",
+			expectedReduced:  "[]",
+			expectedOk:       true,
+		},
+		{
+			region:           "pt-20",
+			expectedStandard: "16%",
+			expectedReduced:  "[4% 9%]",
+			expectedOk:       true,
+		},
+		{
+			region:           "es-ib",
+			expectedStandard: "21%",
+			expectedReduced:  "[4% 10%]",
+			expectedOk:       true,
+		},
+		{
+			region: "us",
+		},
+		{
+			region: "us-ca",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.region, test.expectedStandard), func(t *testing.T) {
+			var rates VATRates
+			var ok bool
+			if len(test.region) == 2 {
+				rates, ok = DefaultVATTable().Rates(CountryCode(test.region))
+			} else {
+				rates, ok = DefaultVATTable().SubdivisionRates(Subdivision(test.region))
+			}
+			if ok != test.expectedOk {
+				t.Fatalf("expected ok: %v, got: %v", test.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if rates.Standard.String() != test.expectedStandard {
+				t.Errorf("expected standard: %v, got: %v", test.expectedStandard, rates.Standard)
+			}
+			if reduced := fmt.Sprint(rates.Reduced); reduced != test.expectedReduced {
+				t.Errorf("expected reduced: %v, got: %v", test.expectedReduced, reduced)
+			}
+		})
+	}
+}
+
+func TestNewVATTable(t *testing.T) {
+	for index, test := range []struct {
+		csv           string
+		expectedError string
+	}{
+		{
+			csv: "region,standard,reduced\nxx,10%,5%\nxx-a,12%,\n",
+		},
+		{
+			csv:           "country,standard,reduced\nxx,10%,\n",
+			expectedError: "unexpected header",
+		},
+		{
+			csv:           "region,standard,reduced\nxx,10%,5%\nyy,ten,\n",
+			expectedError: "line 3: invalid percentage",
+		},
+		{
+			csv:           "region,standard,reduced\nxx,10%,5% five\n",
+			expectedError: "line 2: invalid percentage",
+		},
+		{
+			csv:           "region,standard,reduced\nxyz,10%,\n",
+			expectedError: "invalid subdivision",
+		},
+		{
+			csv:           "region,standard,reduced\nxx,10%,\nXX,11%,\n",
+			expectedError: "duplicate region",
+		},
+		{
+			csv:           "region,standard,reduced\nxx,10%\n",
+			expectedError: "wrong number of fields",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v", index+1, test.expectedError), func(t *testing.T) {
+			table, err := NewVATTable(strings.NewReader(test.csv))
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Errorf("expected error: %s, got none", test.expectedError)
+			}
+
+			rates, ok := table.SubdivisionRates("xx-a")
+			if !ok || rates.Standard.String() != "12%" {
+				t.Fatalf("expected 12%%, got: %v", rates.Standard)
+			}
+			rates, ok = table.SubdivisionRates("xx-b")
+			if !ok || rates.Standard.String() != "10%" {
+				t.Fatalf("expected 10%%, got: %v", rates.Standard)
+			}
+		})
+	}
+}
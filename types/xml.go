@@ -0,0 +1,38 @@
+package types
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// marshalCodeXML omits the element of the empty code.
+func marshalCodeXML[T ~string](c T, e *xml.Encoder, start xml.StartElement) error {
+	if c == "" {
+		return nil
+	}
+
+	return e.EncodeElement(string(c), start)
+}
+
+// unmarshalCodeXML trims the whitespace around the code.
+func unmarshalCodeXML[T ~string](c *T, d *xml.Decoder, start xml.StartElement, parse func(string) (T, error)) error {
+	var str string
+	if err := d.DecodeElement(&str, &start); err != nil {
+		return err
+	}
+
+	return unmarshalCodeText(c, []byte(strings.TrimSpace(str)), parse)
+}
+
+// marshalCodeXMLAttr omits the attribute of the empty code.
+func marshalCodeXMLAttr[T ~string](c T, name xml.Name) (xml.Attr, error) {
+	if c == "" {
+		return xml.Attr{}, nil
+	}
+
+	return xml.Attr{Name: name, Value: string(c)}, nil
+}
+
+func unmarshalCodeXMLAttr[T ~string](c *T, attr xml.Attr, parse func(string) (T, error)) error {
+	return unmarshalCodeText(c, []byte(strings.TrimSpace(attr.Value)), parse)
+}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type xmlAmount struct {
+	Currency Currency `xml:"Ccy,attr"`
+	Value    Decimal  `xml:",chardata"`
+}
+
+type xmlPayment struct {
+	XMLName  xml.Name    `xml:"Pmt"`
+	Amount   xmlAmount   `xml:"InstdAmt"`
+	Country  CountryCode `xml:"Ctry"`
+	Language Language    `xml:"lang,attr,omitempty"`
+}
+
+func TestXML(t *testing.T) {
+	for index, test := range []struct {
+		xml           string
+		expectedValue xmlPayment
+		expectedError string
+	}{
+		{
+			xml: `<Pmt lang="en"><InstdAmt Ccy="EUR">12.50</InstdAmt><Ctry>HU</Ctry></Pmt>`,
+			expectedValue: xmlPayment{
+				XMLName:  xml.Name{Local: "Pmt"},
+				Amount:   xmlAmount{Currency: "eur", Value: MustDecimal("12.50")},
+				Country:  "hu",
+				Language: "en",
+			},
+		},
+		{
+			xml: `<Pmt><InstdAmt Ccy=" huf ">1000</InstdAmt><Ctry>
+				NO
+			</Ctry></Pmt>`,
+			expectedValue: xmlPayment{
+				XMLName: xml.Name{Local: "Pmt"},
+				Amount:  xmlAmount{Currency: "huf", Value: MustDecimal("1000")},
+				Country: "no",
+			},
+		},
+		{
+			xml:           `<Pmt><InstdAmt Ccy="EURO">12.50</InstdAmt></Pmt>`,
+			expectedError: "invalid currency: EURO",
+		},
+		{
+			xml:           `<Pmt><Ctry>HUN</Ctry></Pmt>`,
+			expectedError: "invalid country code: HUN",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.xml, test.expectedValue), func(t *testing.T) {
+			var result xmlPayment
+			err := xml.Unmarshal([]byte(test.xml), &result)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+			if fmt.Sprint(result) != fmt.Sprint(test.expectedValue) {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestSetXMLOutputCase(t *testing.T) {
+	defer ResetXMLOutputCase[Currency]()
+	defer ResetXMLOutputCase[CountryCode]()
+	SetXMLOutputCase[Currency](UpperCase)
+	SetXMLOutputCase[CountryCode](UpperCase)
+
+	payment := xmlPayment{Amount: xmlAmount{Currency: "eur", Value: MustDecimal("12.50")}, Country: "hu"}
+
+	b, err := xml.Marshal(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<Pmt><InstdAmt Ccy="EUR">12.50</InstdAmt><Ctry>HU</Ctry></Pmt>`; string(b) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, string(b))
+	}
+
+	b, err = json.Marshal(payment.Amount.Currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `"eur"`; string(b) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, string(b))
+	}
+
+	b, err = xml.Marshal(xmlPayment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<Pmt><InstdAmt>0</InstdAmt></Pmt>`; string(b) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, string(b))
+	}
+}
+
+func TestResetXMLOutputCase(t *testing.T) {
+	defer SetOutputCase[Currency](LowerCase)
+	SetOutputCase[Currency](UpperCase)
+	SetXMLOutputCase[Currency](LowerCase)
+
+	for index, test := range []struct {
+		reset         bool
+		expectedValue string
+	}{
+		{reset: false, expectedValue: `<xmlAmount Ccy="eur">1</xmlAmount>`},
+		{reset: true, expectedValue: `<xmlAmount Ccy="EUR">1</xmlAmount>`},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.reset, test.expectedValue), func(t *testing.T) {
+			if test.reset {
+				ResetXMLOutputCase[Currency]()
+			}
+
+			b, err := xml.Marshal(xmlAmount{Currency: "eur", Value: MustDecimal("1")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, string(b))
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// marshalCodeYAML marshals the empty code to null.
+func marshalCodeYAML[T ~string](c T) (interface{}, error) {
+	if c == "" {
+		return nil, nil
+	}
+
+	return string(c), nil
+}
+
+// unmarshalCodeYAML leaves c unchanged for null, and adds the line number to errors. The value of the node is used
+// as is, so eg. NO is Norway, not false.
+func unmarshalCodeYAML[T ~string](c *T, node *yaml.Node, parse func(string) (T, error)) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("yaml: line %d: cannot unmarshal %s into %T", node.Line, node.ShortTag(), *c)
+	}
+
+	if node.ShortTag() == "!!null" {
+		return nil
+	}
+
+	code, err := parse(node.Value)
+	if err != nil {
+		return fmt.Errorf("yaml: line %d: %w", node.Line, err)
+	}
+
+	*c = code
+
+	return nil
+}
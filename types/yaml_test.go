@@ -0,0 +1,98 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type yamlConfig struct {
+	Country    CountryCode   `yaml:"country"`
+	Currencies []Currency    `yaml:"currencies"`
+	Language   *Language     `yaml:"language"`
+	TimeZone   TimeZone      `yaml:"time_zone"`
+	Regions    []Subdivision `yaml:"regions,omitempty"`
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	hu := Language("hu")
+
+	for index, test := range []struct {
+		yaml          string
+		expectedValue yamlConfig
+		expectedError string
+	}{
+		{
+			yaml:          "country: NO\ncurrencies: [EUR, nok]\nlanguage: hu\ntime_zone: Europe/Oslo\n",
+			expectedValue: yamlConfig{Country: "no", Currencies: []Currency{"eur", "nok"}, Language: &hu, TimeZone: "Europe/Oslo"},
+		},
+		{
+			yaml:          "country: ~\ncurrencies: ['', EUR]\nlanguage: null\n",
+			expectedValue: yamlConfig{Currencies: []Currency{"", "eur"}},
+		},
+		{
+			yaml:          "country: hu\ncurrencies:\n  - eur\n  - euro\n",
+			expectedError: "yaml: line 4: invalid currency: euro",
+		},
+		{
+			yaml:          "country: hu\n\ntime_zone: Europe/Nowhere\n",
+			expectedError: "yaml: line 3: invalid time zone: Europe/Nowhere: unknown code",
+		},
+		{
+			yaml:          "country: 348\n",
+			expectedError: "yaml: line 1: invalid country code: 348",
+		},
+		{
+			yaml:          "country:\n  code: hu\n",
+			expectedError: "yaml: line 2: cannot unmarshal !!map into types.CountryCode",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %q -> %v", index+1, test.yaml, test.expectedValue), func(t *testing.T) {
+			var result yamlConfig
+			err := yaml.Unmarshal([]byte(test.yaml), &result)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+			if !reflect.DeepEqual(result, test.expectedValue) {
+				t.Fatalf("expected: %+v, got: %+v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestUnmarshalYAMLError(t *testing.T) {
+	var result yamlConfig
+	err := yaml.Unmarshal([]byte("regions:\n  - hu-bu\n  - hu\n"), &result)
+
+	var codeErr *InvalidCodeError
+	if !errors.As(err, &codeErr) {
+		t.Fatalf("expected *InvalidCodeError, got: %T: %v", err, err)
+	}
+	if codeErr.Input != "hu" || !errors.Is(err, ErrInvalidFormat) {
+		t.Fatalf("expected: invalid format of hu, got: %v", err)
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	defer SetOutputCase[Currency](LowerCase)
+	SetOutputCase[Currency](UpperCase)
+
+	b, err := yaml.Marshal(yamlConfig{Country: "no", Currencies: []Currency{"eur", ""}, TimeZone: "Europe/Oslo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "country: \"no\"\ncurrencies:\n    - EUR\n    - null\nlanguage: null\ntime_zone: Europe/Oslo\n"
+	if string(b) != expected {
+		t.Fatalf("expected: %q, got: %q", expected, b)
+	}
+}
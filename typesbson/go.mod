@@ -0,0 +1,13 @@
+module github.com/proemergotech/types/typesbson
+
+go 1.18
+
+require (
+	github.com/proemergotech/types v1.4.0
+	go.mongodb.org/mongo-driver v1.11.0
+)
+
+require (
+	github.com/ugorji/go v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
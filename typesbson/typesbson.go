@@ -0,0 +1,144 @@
+// Package typesbson marshals CountryCode, Currency and Language to BSON for MongoDB, with the null semantics of
+// their MarshalJSON: empty codes are stored as null, and decoded strings are validated by the type constructors.
+//
+// Either use the Currency, CountryCode and Language types of this package in documents, or register the codecs of the
+// types package with Register:
+//
+//	registry := typesbson.Register(bson.NewRegistryBuilder()).Build()
+//	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri).SetRegistry(registry))
+//
+// It is a separate module, so the types module does not depend on the MongoDB driver.
+package typesbson
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+
+	"github.com/proemergotech/types/types"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// Currency is a types.Currency implementing bson.ValueMarshaler and bson.ValueUnmarshaler.
+type Currency types.Currency
+
+func (c Currency) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalValue(types.Currency(c))
+}
+
+func (c *Currency) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	return unmarshalValue((*types.Currency)(c), t, b, types.NewCurrency)
+}
+
+// CountryCode is a types.CountryCode implementing bson.ValueMarshaler and bson.ValueUnmarshaler.
+type CountryCode types.CountryCode
+
+func (c CountryCode) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalValue(types.CountryCode(c))
+}
+
+func (c *CountryCode) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	return unmarshalValue((*types.CountryCode)(c), t, b, types.NewCountryCode)
+}
+
+// Language is a types.Language implementing bson.ValueMarshaler and bson.ValueUnmarshaler.
+type Language types.Language
+
+func (l Language) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return marshalValue(types.Language(l))
+}
+
+func (l *Language) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	return unmarshalValue((*types.Language)(l), t, b, types.NewLanguage)
+}
+
+// Register registers the codecs of types.CountryCode, types.Currency and types.Language on rb and returns it.
+func Register(rb *bsoncodec.RegistryBuilder) *bsoncodec.RegistryBuilder {
+	registerCode(rb, types.NewCurrency)
+	registerCode(rb, types.NewCountryCode)
+	registerCode(rb, types.NewLanguage)
+
+	return rb
+}
+
+type textCode interface {
+	~string
+	encoding.TextMarshaler
+}
+
+func registerCode[T textCode](rb *bsoncodec.RegistryBuilder, parse func(string) (T, error)) {
+	t := reflect.TypeOf(T(""))
+
+	rb.RegisterTypeEncoder(t, bsoncodec.ValueEncoderFunc(func(_ bsoncodec.EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+		if !val.IsValid() || val.Type() != t {
+			return bsoncodec.ValueEncoderError{Name: "CodeEncodeValue", Types: []reflect.Type{t}, Received: val}
+		}
+
+		bsonType, b, err := marshalValue(T(val.String()))
+		if err != nil {
+			return err
+		}
+
+		return bsonrw.Copier{}.CopyValueFromBytes(vw, bsonType, b)
+	}))
+
+	rb.RegisterTypeDecoder(t, bsoncodec.ValueDecoderFunc(func(_ bsoncodec.DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+		if !val.CanSet() || val.Type() != t {
+			return bsoncodec.ValueDecoderError{Name: "CodeDecodeValue", Types: []reflect.Type{t}, Received: val}
+		}
+
+		bsonType, b, err := bsonrw.Copier{}.CopyValueToBytes(vr)
+		if err != nil {
+			return err
+		}
+
+		var c T
+		if err := unmarshalValue(&c, bsonType, b, parse); err != nil {
+			return err
+		}
+
+		val.SetString(string(c))
+
+		return nil
+	}))
+}
+
+func marshalValue[T textCode](c T) (bsontype.Type, []byte, error) {
+	if c == "" {
+		return bsontype.Null, nil, nil
+	}
+
+	text, err := c.MarshalText()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return bsontype.String, bsoncore.AppendString(nil, string(text)), nil
+}
+
+func unmarshalValue[T ~string](c *T, t bsontype.Type, b []byte, parse func(string) (T, error)) error {
+	switch t {
+	case bsontype.Null, bsontype.Undefined:
+		*c = ""
+		return nil
+	case bsontype.String:
+		str, _, ok := bsoncore.ReadString(b)
+		if !ok {
+			return fmt.Errorf("invalid bson string value")
+		}
+
+		code, err := parse(str)
+		if err != nil {
+			return err
+		}
+
+		*c = code
+
+		return nil
+	default:
+		return fmt.Errorf("cannot unmarshal bson %s to %T", t, *c)
+	}
+}
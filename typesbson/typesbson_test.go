@@ -0,0 +1,129 @@
+package typesbson
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type wrapperDocument struct {
+	Country  CountryCode `bson:"country"`
+	Currency Currency    `bson:"currency"`
+	Language Language    `bson:"language"`
+}
+
+type document struct {
+	Country  types.CountryCode `bson:"country"`
+	Currency *types.Currency   `bson:"currency"`
+	Language types.Language    `bson:"language"`
+	Accepted []types.Currency  `bson:"accepted"`
+}
+
+func TestWrappers(t *testing.T) {
+	for index, test := range []struct {
+		doc           wrapperDocument
+		expectedValue bson.M
+	}{
+		{
+			doc:           wrapperDocument{Country: "hu", Currency: "huf", Language: "hu"},
+			expectedValue: bson.M{"country": "hu", "currency": "huf", "language": "hu"},
+		},
+		{
+			doc:           wrapperDocument{},
+			expectedValue: bson.M{"country": nil, "currency": nil, "language": nil},
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.doc, test.expectedValue), func(t *testing.T) {
+			b, err := bson.Marshal(test.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var raw bson.M
+			if err := bson.Unmarshal(b, &raw); err != nil {
+				t.Fatal(err)
+			}
+			if fmt.Sprint(raw) != fmt.Sprint(test.expectedValue) {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, raw)
+			}
+
+			var result wrapperDocument
+			if err := bson.Unmarshal(b, &result); err != nil {
+				t.Fatal(err)
+			}
+			if result != test.doc {
+				t.Fatalf("expected: %v, got: %v", test.doc, result)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registry := Register(bson.NewRegistryBuilder()).Build()
+
+	currency := types.Currency("eur")
+	doc := document{Country: "de", Currency: &currency, Accepted: []types.Currency{"eur", "usd"}}
+
+	b, err := bson.MarshalWithRegistry(registry, doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw bson.M
+	if err := bson.Unmarshal(b, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "map[accepted:[eur usd] country:de currency:eur language:<nil>]"; fmt.Sprint(raw) != expected {
+		t.Fatalf("expected: %v, got: %v", expected, raw)
+	}
+
+	var result document
+	if err := bson.UnmarshalWithRegistry(registry, b, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Country != "de" || result.Currency == nil || *result.Currency != "eur" || result.Language != "" || len(result.Accepted) != 2 {
+		t.Fatalf("expected: %v, got: %v", doc, result)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	registry := Register(bson.NewRegistryBuilder()).Build()
+
+	for index, test := range []struct {
+		doc           bson.M
+		expectedError string
+	}{
+		{
+			doc:           bson.M{"country": "HUN"},
+			expectedError: "invalid country code: HUN",
+		},
+		{
+			doc:           bson.M{"language": "e1"},
+			expectedError: "invalid language: e1",
+		},
+		{
+			doc:           bson.M{"currency": 978},
+			expectedError: "cannot unmarshal bson 32-bit integer",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.doc, test.expectedError), func(t *testing.T) {
+			b, err := bson.Marshal(test.doc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var wrapper wrapperDocument
+			if err := bson.Unmarshal(b, &wrapper); err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("expected error: %s, got: %v", test.expectedError, err)
+			}
+
+			var doc document
+			if err := bson.UnmarshalWithRegistry(registry, b, &doc); err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("expected error: %s, got: %v", test.expectedError, err)
+			}
+		})
+	}
+}
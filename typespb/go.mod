@@ -0,0 +1,14 @@
+module github.com/proemergotech/types/typespb
+
+go 1.18
+
+require (
+	github.com/proemergotech/types v1.4.0
+	google.golang.org/genproto v0.0.0-20220519153652-3a47de7e79bd
+	google.golang.org/protobuf v1.28.1
+)
+
+require (
+	github.com/ugorji/go v1.1.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
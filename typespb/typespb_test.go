@@ -0,0 +1,145 @@
+package typespb
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/proemergotech/types/types"
+	"google.golang.org/genproto/googleapis/type/money"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCodes(t *testing.T) {
+	currency, err := ToCurrency(FromCurrency("huf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if currency != "huf" {
+		t.Errorf("expected: huf, got: %v", currency)
+	}
+
+	country, err := ToCountryCode(FromCountryCode("t1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if country != "t1" {
+		t.Errorf("expected: t1, got: %v", country)
+	}
+
+	language, err := ToLanguage(FromLanguage("en"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if language != "en" {
+		t.Errorf("expected: en, got: %v", language)
+	}
+
+	if m := FromCurrency("eur"); m.GetCode() != "EUR" {
+		t.Errorf("expected: EUR, got: %v", m.GetCode())
+	}
+	if m := FromCurrency(""); m != nil {
+		t.Errorf("expected: nil, got: %v", m)
+	}
+	if c, err := ToCountryCode(nil); err != nil || c != "" {
+		t.Errorf("expected: empty, got: %v, %v", c, err)
+	}
+	if _, err := ToLanguage(&Language{Code: "eng"}); err == nil || !strings.Contains(err.Error(), "invalid language: eng") {
+		t.Errorf("expected error: invalid language: eng, got: %v", err)
+	}
+}
+
+func TestCodesWire(t *testing.T) {
+	b, err := proto.Marshal(FromCountryCode("hu"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m CountryCode
+	if err := proto.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	country, err := ToCountryCode(&m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if country != "hu" {
+		t.Errorf("expected: hu, got: %v", country)
+	}
+}
+
+func TestFromMoney(t *testing.T) {
+	for index, test := range []struct {
+		amount        string
+		currency      types.Currency
+		expectedUnits int64
+		expectedNanos int32
+		expectedError string
+	}{
+		{amount: "12.50", currency: "eur", expectedUnits: 12, expectedNanos: 500000000},
+		{amount: "-1.75", currency: "usd", expectedUnits: -1, expectedNanos: -750000000},
+		{amount: "-0.000000001", currency: "usd", expectedUnits: 0, expectedNanos: -1},
+		{amount: "1000", currency: "huf", expectedUnits: 1000, expectedNanos: 0},
+		{amount: "0.1234567890", currency: "btc", expectedUnits: 0, expectedNanos: 123456789},
+		{amount: "9223372036854775807.999999999", currency: "eur", expectedUnits: 9223372036854775807, expectedNanos: 999999999},
+		{amount: "0.0000000001", currency: "btc", expectedError: "more than 9 decimals"},
+		{amount: "9223372036854775808", currency: "eur", expectedError: "overflows int64 units"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v %v -> %v, %v", index+1, test.amount, test.currency, test.expectedUnits, test.expectedNanos), func(t *testing.T) {
+			m, err := FromMoney(types.Money{Amount: types.MustDecimal(test.amount), Currency: test.currency})
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			if m.CurrencyCode != test.currency.Upper() {
+				t.Errorf("expected currency: %v, got: %v", test.currency.Upper(), m.CurrencyCode)
+			}
+			if m.Units != test.expectedUnits || m.Nanos != test.expectedNanos {
+				t.Errorf("expected: %v, %v, got: %v, %v", test.expectedUnits, test.expectedNanos, m.Units, m.Nanos)
+			}
+		})
+	}
+}
+
+func TestToMoney(t *testing.T) {
+	types.RegisterCryptoCurrencies()
+
+	for index, test := range []struct {
+		money         *money.Money
+		expectedValue string
+		expectedError string
+	}{
+		{money: &money.Money{CurrencyCode: "EUR", Units: 12, Nanos: 500000000}, expectedValue: "12.50 EUR"},
+		{money: &money.Money{CurrencyCode: "EUR", Units: 3}, expectedValue: "3.00 EUR"},
+		{money: &money.Money{CurrencyCode: "USD", Units: -1, Nanos: -750000000}, expectedValue: "-1.75 USD"},
+		{money: &money.Money{CurrencyCode: "USD", Nanos: -1}, expectedValue: "-0.000000001 USD"},
+		{money: &money.Money{CurrencyCode: "HUF", Units: 1000}, expectedValue: "1000.00 HUF"},
+		{money: &money.Money{CurrencyCode: "BTC", Nanos: 1}, expectedValue: "0.000000001 BTC"},
+		{money: nil, expectedValue: "0"},
+		{money: &money.Money{CurrencyCode: "EUR", Units: 1, Nanos: 1000000000}, expectedError: "nanos out of range"},
+		{money: &money.Money{CurrencyCode: "EUR", Units: 1, Nanos: -1}, expectedError: "different signs"},
+		{money: &money.Money{CurrencyCode: "EURO", Units: 1}, expectedError: "invalid currency: EURO"},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.money, test.expectedValue), func(t *testing.T) {
+			m, err := ToMoney(test.money)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			if result := m.String(); result != test.expectedValue {
+				t.Fatalf("expected: %v, got: %v", test.expectedValue, result)
+			}
+		})
+	}
+}
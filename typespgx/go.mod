@@ -0,0 +1,19 @@
+module github.com/proemergotech/types/typespgx
+
+go 1.18
+
+require (
+	github.com/jackc/pgx/v5 v5.2.0
+	github.com/proemergotech/types v1.4.0
+)
+
+require (
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20200714003250-2b9c44734f2b // indirect
+	github.com/kr/text v0.1.0 // indirect
+	github.com/rogpeppe/go-internal v1.6.1 // indirect
+	github.com/ugorji/go v1.1.1 // indirect
+	golang.org/x/crypto v0.0.0-20220829220503-c86fa9a7ed90 // indirect
+	golang.org/x/text v0.3.8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
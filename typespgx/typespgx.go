@@ -0,0 +1,113 @@
+// Package typespgx registers the code types with pgx, so they are validated when scanned from text, varchar, enum
+// or domain columns and their arrays, eg. currency[]. Without it pgx scans them as their underlying string type.
+//
+// Register the types on every connection, eg. in pgxpool.Config.AfterConnect:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		typespgx.Register(conn.TypeMap())
+//
+//		return typespgx.RegisterPgType[types.Currency](ctx, conn, "currency")
+//	}
+//
+// It is a separate module, so the types module does not depend on pgx.
+package typespgx
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/proemergotech/types/types"
+)
+
+// Register registers CountryCode, Currency, Language, Subdivision, Nationality and TimeZone on m. Call it before
+// the first query, scan plans are cached by m.
+func Register(m *pgtype.Map) {
+	RegisterCode(m, types.NewCountryCode)
+	RegisterCode(m, types.NewCurrency)
+	RegisterCode(m, types.NewLanguage)
+	RegisterCode(m, types.NewSubdivision)
+	RegisterCode(m, types.NewNationality)
+	RegisterCode(m, types.NewTimeZone)
+}
+
+// RegisterCode registers the code type T on m, scanned values are parsed with parse, eg.
+// RegisterCode(m, types.NewCode[airportCodeSpec]). Values are encoded by their driver.Valuer implementation.
+func RegisterCode[T ~string](m *pgtype.Map, parse func(string) (T, error)) {
+	tryWrap := func(target interface{}) (pgtype.WrappedScanPlanNextSetter, interface{}, bool) {
+		if _, ok := target.(*T); !ok {
+			return nil, nil, false
+		}
+
+		return &codeScanPlan[T]{parse: parse}, &pgtype.Text{}, true
+	}
+
+	m.TryWrapScanPlanFuncs = append([]pgtype.TryWrapScanPlanFunc{tryWrap}, m.TryWrapScanPlanFuncs...)
+}
+
+// RegisterPgType loads the PostgreSQL enum or domain typeName and its array type, registers them on the type map of
+// conn, and makes them the default types of T and []T, eg. RegisterPgType[types.Currency](ctx, conn, "currency").
+func RegisterPgType[T ~string](ctx context.Context, conn *pgx.Conn, typeName string) error {
+	return registerPgType[T](ctx, conn, typeName)
+}
+
+// pgTypeLoader is implemented by *pgx.Conn.
+type pgTypeLoader interface {
+	LoadType(ctx context.Context, typeName string) (*pgtype.Type, error)
+	TypeMap() *pgtype.Map
+}
+
+func registerPgType[T ~string](ctx context.Context, conn pgTypeLoader, typeName string) error {
+	t, err := conn.LoadType(ctx, typeName)
+	if err != nil {
+		return err
+	}
+	conn.TypeMap().RegisterType(t)
+
+	arrayType, err := conn.LoadType(ctx, "_"+typeName)
+	if err != nil {
+		return err
+	}
+	conn.TypeMap().RegisterType(arrayType)
+
+	registerDefaultPgType[T](conn.TypeMap(), t.Name, arrayType.Name)
+
+	return nil
+}
+
+func registerDefaultPgType[T ~string](m *pgtype.Map, typeName, arrayTypeName string) {
+	m.RegisterDefaultPgType(T(""), typeName)
+	m.RegisterDefaultPgType([]T(nil), arrayTypeName)
+}
+
+// codeScanPlan scans to pgtype.Text with the next plan, then parses the text, NULL is scanned to the empty code.
+type codeScanPlan[T ~string] struct {
+	parse func(string) (T, error)
+	next  pgtype.ScanPlan
+}
+
+func (p *codeScanPlan[T]) SetNext(next pgtype.ScanPlan) {
+	p.next = next
+}
+
+func (p *codeScanPlan[T]) Scan(src []byte, target interface{}) error {
+	var text pgtype.Text
+	if err := p.next.Scan(src, &text); err != nil {
+		return err
+	}
+
+	c := target.(*T)
+	if !text.Valid {
+		*c = ""
+		return nil
+	}
+
+	code, err := p.parse(text.String)
+	if err != nil {
+		return err
+	}
+
+	*c = code
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package typespgx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/proemergotech/types/types"
+)
+
+const (
+	currencyOID      = 100001
+	currencyArrayOID = 100002
+	countryOID       = 100003
+)
+
+// fakeConn loads a currency enum, its array, and a country domain over text, like pgx.Conn.LoadType from pg_type.
+type fakeConn struct {
+	typeMap *pgtype.Map
+	loaded  []string
+}
+
+func (c *fakeConn) LoadType(_ context.Context, typeName string) (*pgtype.Type, error) {
+	c.loaded = append(c.loaded, typeName)
+
+	switch typeName {
+	case "currency":
+		return &pgtype.Type{Name: "currency", OID: currencyOID, Codec: &pgtype.EnumCodec{}}, nil
+	case "_currency":
+		elementType, ok := c.typeMap.TypeForOID(currencyOID)
+		if !ok {
+			return nil, errors.New("element type not registered: currency")
+		}
+		return &pgtype.Type{Name: "_currency", OID: currencyArrayOID, Codec: &pgtype.ArrayCodec{ElementType: elementType}}, nil
+	case "country":
+		textType, _ := c.typeMap.TypeForOID(pgtype.TextOID)
+		return &pgtype.Type{Name: "country", OID: countryOID, Codec: textType.Codec}, nil
+	}
+
+	return nil, fmt.Errorf("type not found: %s", typeName)
+}
+
+func (c *fakeConn) TypeMap() *pgtype.Map {
+	return c.typeMap
+}
+
+// newTypeMap returns a type map with a currency enum and a country domain over text, as loaded by RegisterPgType.
+func newTypeMap() *pgtype.Map {
+	conn := &fakeConn{typeMap: pgtype.NewMap()}
+	Register(conn.typeMap)
+
+	if err := registerPgType[types.Currency](context.Background(), conn, "currency"); err != nil {
+		panic(err)
+	}
+	country, _ := conn.LoadType(context.Background(), "country")
+	conn.typeMap.RegisterType(country)
+
+	return conn.typeMap
+}
+
+func TestScan(t *testing.T) {
+	m := newTypeMap()
+
+	for index, test := range []struct {
+		oid           uint32
+		format        int16
+		src           []byte
+		target        interface{}
+		expectedValue interface{}
+		expectedError string
+	}{
+		{
+			oid:           pgtype.TextOID,
+			src:           []byte("EUR"),
+			target:        new(types.Currency),
+			expectedValue: types.Currency("eur"),
+		},
+		{
+			oid:           pgtype.TextOID,
+			format:        pgtype.BinaryFormatCode,
+			src:           []byte("huf"),
+			target:        new(types.Currency),
+			expectedValue: types.Currency("huf"),
+		},
+		{
+			oid:           pgtype.VarcharOID,
+			src:           nil,
+			target:        new(types.Currency),
+			expectedValue: types.Currency(""),
+		},
+		{
+			oid:           pgtype.BPCharOID,
+			src:           []byte("HU"),
+			target:        new(types.CountryCode),
+			expectedValue: types.CountryCode("hu"),
+		},
+		{
+			oid:           currencyOID,
+			src:           []byte("usd"),
+			target:        new(types.Currency),
+			expectedValue: types.Currency("usd"),
+		},
+		{
+			oid:           countryOID,
+			src:           []byte("NO"),
+			target:        new(types.CountryCode),
+			expectedValue: types.CountryCode("no"),
+		},
+		{
+			oid:           pgtype.TextOID,
+			src:           []byte("Europe/Budapest"),
+			target:        new(types.TimeZone),
+			expectedValue: types.TimeZone("Europe/Budapest"),
+		},
+		{
+			oid:           pgtype.TextOID,
+			src:           nil,
+			target:        new(*types.Language),
+			expectedValue: (*types.Language)(nil),
+		},
+		{
+			oid:           pgtype.TextArrayOID,
+			src:           []byte("{EUR,huf,NULL}"),
+			target:        new([]types.Currency),
+			expectedValue: []types.Currency{"eur", "huf", ""},
+		},
+		{
+			oid:           currencyArrayOID,
+			src:           []byte("{eur,usd}"),
+			target:        new([]types.Currency),
+			expectedValue: []types.Currency{"eur", "usd"},
+		},
+		{
+			oid:           pgtype.TextOID,
+			src:           []byte("EURO"),
+			target:        new(types.Currency),
+			expectedError: "invalid currency: EURO",
+		},
+		{
+			oid:           pgtype.TextArrayOID,
+			src:           []byte("{en,eng}"),
+			target:        new([]types.Language),
+			expectedError: "invalid language: eng",
+		},
+		{
+			oid:           0,
+			format:        pgtype.BinaryFormatCode,
+			src:           []byte("hu-bu"),
+			target:        new(types.Subdivision),
+			expectedValue: types.Subdivision("hu-bu"),
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %s -> %v", index+1, test.src, test.expectedValue), func(t *testing.T) {
+			err := m.Scan(test.oid, test.format, test.src, test.target)
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			if result := reflect.ValueOf(test.target).Elem().Interface(); !reflect.DeepEqual(result, test.expectedValue) {
+				t.Fatalf("expected: %#v, got: %#v", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	m := newTypeMap()
+
+	for index, test := range []struct {
+		oid           uint32
+		value         interface{}
+		expectedValue []byte
+	}{
+		{
+			oid:           pgtype.TextOID,
+			value:         types.Currency("eur"),
+			expectedValue: []byte("eur"),
+		},
+		{
+			oid:           pgtype.TextOID,
+			value:         types.Currency(""),
+			expectedValue: nil,
+		},
+		{
+			oid:           currencyOID,
+			value:         types.Currency("huf"),
+			expectedValue: []byte("huf"),
+		},
+		{
+			oid:           0,
+			value:         types.Currency("usd"),
+			expectedValue: []byte("usd"),
+		},
+		{
+			oid:           currencyArrayOID,
+			value:         []types.Currency{"eur", ""},
+			expectedValue: []byte("{eur,NULL}"),
+		},
+		{
+			oid:           pgtype.TextArrayOID,
+			value:         []types.CountryCode{"hu", "no"},
+			expectedValue: []byte("{hu,no}"),
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %s", index+1, test.value, test.expectedValue), func(t *testing.T) {
+			result, err := m.Encode(test.oid, pgtype.TextFormatCode, test.value, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(result) != string(test.expectedValue) || (result == nil) != (test.expectedValue == nil) {
+				t.Fatalf("expected: %q, got: %q", test.expectedValue, result)
+			}
+		})
+	}
+}
+
+func TestRegisterDefaultPgType(t *testing.T) {
+	m := newTypeMap()
+
+	if dt, ok := m.TypeForValue(types.Currency("")); !ok || dt.OID != currencyOID {
+		t.Errorf("expected: %v, got: %v", currencyOID, dt)
+	}
+	if dt, ok := m.TypeForValue([]types.Currency(nil)); !ok || dt.OID != currencyArrayOID {
+		t.Errorf("expected: %v, got: %v", currencyArrayOID, dt)
+	}
+}
+
+func TestRegisterPgType(t *testing.T) {
+	for index, test := range []struct {
+		typeName       string
+		expectedLoaded []string
+		expectedError  string
+	}{
+		{
+			typeName:       "currency",
+			expectedLoaded: []string{"currency", "_currency"},
+		},
+		{
+			typeName:       "language",
+			expectedLoaded: []string{"language"},
+			expectedError:  "type not found: language",
+		},
+		{
+			// a domain without an array type
+			typeName:       "country",
+			expectedLoaded: []string{"country", "_country"},
+			expectedError:  "type not found: _country",
+		},
+	} {
+		t.Run(fmt.Sprintf("Case %d: %v -> %v", index+1, test.typeName, test.expectedLoaded), func(t *testing.T) {
+			conn := &fakeConn{typeMap: pgtype.NewMap()}
+
+			err := registerPgType[types.Currency](context.Background(), conn, test.typeName)
+			if !reflect.DeepEqual(conn.loaded, test.expectedLoaded) {
+				t.Errorf("expected loaded: %v, got: %v", test.expectedLoaded, conn.loaded)
+			}
+			if err != nil {
+				if test.expectedError != "" && strings.Contains(err.Error(), test.expectedError) {
+					if dt, ok := conn.typeMap.TypeForValue(types.Currency("")); ok && dt.Name == test.typeName {
+						t.Errorf("expected no default type on error, got: %v", dt.Name)
+					}
+					return
+				}
+				t.Fatal(err)
+			} else if test.expectedError != "" {
+				t.Fatalf("expected error: %s, got none", test.expectedError)
+			}
+
+			if dt, ok := conn.typeMap.TypeForValue(types.Currency("")); !ok || dt.OID != currencyOID {
+				t.Errorf("expected: %v, got: %v", currencyOID, dt)
+			}
+			if dt, ok := conn.typeMap.TypeForValue([]types.Currency(nil)); !ok || dt.OID != currencyArrayOID {
+				t.Errorf("expected: %v, got: %v", currencyArrayOID, dt)
+			}
+		})
+	}
+}